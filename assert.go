@@ -13,7 +13,7 @@ import (
 // Example:
 //
 //	AssertNil(t, myValue)
-func AssertNil(t *testing.T, value any) {
+func AssertNil(t testing.TB, value any) {
 	t.Helper()
 
 	if !isNil(value) {
@@ -26,7 +26,7 @@ func AssertNil(t *testing.T, value any) {
 // Example:
 //
 //	AssertTrue(t, methodReturnsTrue())
-func AssertTrue(t *testing.T, value bool) {
+func AssertTrue(t testing.TB, value bool) {
 	t.Helper()
 
 	if !value {
@@ -39,7 +39,7 @@ func AssertTrue(t *testing.T, value bool) {
 // Example:
 //
 //	AssertFalse(t, methodReturnsFalse())
-func AssertFalse(t *testing.T, value bool) {
+func AssertFalse(t testing.TB, value bool) {
 	t.Helper()
 
 	if value {
@@ -52,7 +52,7 @@ func AssertFalse(t *testing.T, value bool) {
 // Example:
 //
 //	AssertNoError(t, err)
-func AssertNoError(t *testing.T, err error) {
+func AssertNoError(t testing.TB, err error) {
 	t.Helper()
 
 	if err != nil {
@@ -65,7 +65,7 @@ func AssertNoError(t *testing.T, err error) {
 // Example:
 //
 //	AssertError(t, err)
-func AssertError(t *testing.T, err error) {
+func AssertError(t testing.TB, err error) {
 	t.Helper()
 
 	if err == nil {
@@ -78,7 +78,7 @@ func AssertError(t *testing.T, err error) {
 // Example:
 //
 //	AssertEqual(t, "a", "b")
-func AssertEqual(t *testing.T, expected any, actual any) {
+func AssertEqual(t testing.TB, expected any, actual any) {
 	t.Helper()
 
 	if !isEqual(expected, actual) {
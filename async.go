@@ -0,0 +1,164 @@
+package odize
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// AssertEventually checks that condition returns true within the timeout, polling every interval.
+//
+// Example:
+//
+//	AssertEventually(t, func() bool { return queue.Len() == 0 }, time.Second, 10*time.Millisecond)
+func AssertEventually(t testing.TB, condition func() bool, timeout time.Duration, interval time.Duration) {
+	t.Helper()
+
+	attempts, ok := pollUntil(timeout, interval, condition)
+	if !ok {
+		log(t, fmt.Sprintf("condition not met within %s after %d attempts", timeout, attempts)+decorateDiff(true, false))
+	}
+}
+
+// AssertConsistently checks that condition returns true for the whole duration, polling every interval.
+//
+// Example:
+//
+//	AssertConsistently(t, func() bool { return cache.Has("key") }, 100*time.Millisecond, 10*time.Millisecond)
+func AssertConsistently(t testing.TB, condition func() bool, duration time.Duration, interval time.Duration) {
+	t.Helper()
+
+	attempts, failed := pollUntil(duration, interval, func() bool {
+		return !condition()
+	})
+	if failed {
+		log(t, fmt.Sprintf("condition stopped holding after %d attempts within %s", attempts, duration)+decorateDiff(true, false))
+	}
+}
+
+// AssertEventuallyCollect retries fn until an attempt makes no failed assertions, or the timeout elapses.
+// On timeout, the failures from the last attempt are reported.
+//
+// Example:
+//
+//	AssertEventuallyCollect(t, func(c *Collect) {
+//		AssertEqual(c, "ready", svc.Status())
+//	}, time.Second, 10*time.Millisecond)
+func AssertEventuallyCollect(t testing.TB, fn func(c *Collect), timeout time.Duration, interval time.Duration) {
+	t.Helper()
+
+	var last *Collect
+	attempts, ok := pollUntil(timeout, interval, func() bool {
+		last = runCollectAttempt(t, fn)
+		return !last.Failed()
+	})
+	if !ok {
+		log(t, fmt.Sprintf("assertions did not pass within %s after %d attempts, last failure:\n%s", timeout, attempts, last.String()))
+	}
+}
+
+// AssertConsistentlyCollect checks that every attempt of fn makes no failed assertions for the whole duration.
+// The failures from the first failed attempt are reported.
+//
+// Example:
+//
+//	AssertConsistentlyCollect(t, func(c *Collect) {
+//		AssertNoError(c, svc.Ping())
+//	}, 100*time.Millisecond, 10*time.Millisecond)
+func AssertConsistentlyCollect(t testing.TB, fn func(c *Collect), duration time.Duration, interval time.Duration) {
+	t.Helper()
+
+	var last *Collect
+	attempts, failed := pollUntil(duration, interval, func() bool {
+		last = runCollectAttempt(t, fn)
+		return last.Failed()
+	})
+	if failed {
+		log(t, fmt.Sprintf("assertions stopped passing after %d attempts within %s, failure:\n%s", attempts, duration, last.String()))
+	}
+}
+
+// Helper - no op, Collect does not report line numbers
+func (c *Collect) Helper() {}
+
+// Fail - mark the attempt as failed
+func (c *Collect) Fail() {
+	c.failed = true
+}
+
+// Failed - reports whether the attempt has failed
+func (c *Collect) Failed() bool {
+	return c.failed
+}
+
+// FailNow - mark the attempt as failed and stop the attempt
+func (c *Collect) FailNow() {
+	c.Fail()
+	runtime.Goexit()
+}
+
+// Error - record a failure message and mark the attempt as failed
+func (c *Collect) Error(args ...any) {
+	c.messages = append(c.messages, strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+	c.Fail()
+}
+
+// Errorf - record a formatted failure message and mark the attempt as failed
+func (c *Collect) Errorf(format string, args ...any) {
+	c.messages = append(c.messages, fmt.Sprintf(format, args...))
+	c.Fail()
+}
+
+// Fatal - record a failure message and stop the attempt
+func (c *Collect) Fatal(args ...any) {
+	c.Error(args...)
+	c.FailNow()
+}
+
+// Fatalf - record a formatted failure message and stop the attempt
+func (c *Collect) Fatalf(format string, args ...any) {
+	c.Errorf(format, args...)
+	c.FailNow()
+}
+
+// String - return recorded failure messages
+func (c *Collect) String() string {
+	return strings.Join(c.messages, "\n")
+}
+
+// runCollectAttempt runs fn in its own goroutine so FailNow only stops the attempt
+func runCollectAttempt(t testing.TB, fn func(c *Collect)) *Collect {
+	c := &Collect{TB: t}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(c)
+	}()
+	<-done
+
+	return c
+}
+
+// pollUntil invokes stop every interval until it returns true or the timeout elapses.
+// Returns the number of attempts and whether stop returned true.
+func pollUntil(timeout time.Duration, interval time.Duration, stop func() bool) (int, bool) {
+	deadline := time.Now().Add(timeout)
+	attempts := 0
+
+	for {
+		attempts++
+		if stop() {
+			return attempts, true
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return attempts, false
+		}
+
+		time.Sleep(min(interval, remaining))
+	}
+}
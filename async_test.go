@@ -0,0 +1,105 @@
+package odize
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAssertEventually(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass once condition is met", func(t *testing.T) {
+			var calls atomic.Int32
+			AssertEventually(t, func() bool {
+				return calls.Add(1) == 3
+			}, time.Second, time.Millisecond)
+			AssertEqual(t, int32(3), calls.Load())
+		}).
+		Test("should report attempts on timeout", func(t *testing.T) {
+			result := runCollectAttempt(t, func(c *Collect) {
+				AssertEventually(c, func() bool { return false }, 10*time.Millisecond, time.Millisecond)
+			})
+			AssertTrue(t, result.Failed())
+			AssertTrue(t, strings.Contains(result.String(), "condition not met within 10ms after"))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestAssertConsistently(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when condition always holds", func(t *testing.T) {
+			AssertConsistently(t, func() bool { return true }, 10*time.Millisecond, time.Millisecond)
+		}).
+		Test("should fail as soon as condition stops holding", func(t *testing.T) {
+			calls := 0
+			result := runCollectAttempt(t, func(c *Collect) {
+				AssertConsistently(c, func() bool {
+					calls++
+					return calls < 2
+				}, time.Second, time.Millisecond)
+			})
+			AssertTrue(t, result.Failed())
+			AssertEqual(t, 2, calls)
+			AssertTrue(t, strings.Contains(result.String(), "after 2 attempts"))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestAssertEventuallyCollect(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should retry assertions until they pass", func(t *testing.T) {
+			calls := 0
+			AssertEventuallyCollect(t, func(c *Collect) {
+				calls++
+				AssertEqual(c, 3, calls)
+			}, time.Second, time.Millisecond)
+			AssertEqual(t, 3, calls)
+		}).
+		Test("should report last failure diff on timeout", func(t *testing.T) {
+			result := runCollectAttempt(t, func(c *Collect) {
+				AssertEventuallyCollect(c, func(c *Collect) {
+					AssertEqual(c, "ready", "pending")
+				}, 10*time.Millisecond, time.Millisecond)
+			})
+			AssertTrue(t, result.Failed())
+			AssertTrue(t, strings.Contains(result.String(), "last failure"))
+			AssertTrue(t, strings.Contains(result.String(), "-\tpending"))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestAssertConsistentlyCollect(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when assertions always pass", func(t *testing.T) {
+			AssertConsistentlyCollect(t, func(c *Collect) {
+				AssertTrue(c, true)
+			}, 10*time.Millisecond, time.Millisecond)
+		}).
+		Test("should report failure when assertions stop passing", func(t *testing.T) {
+			result := runCollectAttempt(t, func(c *Collect) {
+				AssertConsistentlyCollect(c, func(c *Collect) {
+					AssertNoError(c, ErrTestAlreadyExists)
+				}, time.Second, time.Millisecond)
+			})
+			AssertTrue(t, result.Failed())
+			AssertTrue(t, strings.Contains(result.String(), "after 1 attempts"))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
@@ -0,0 +1,195 @@
+package odize
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// attemptTestEnv is the environment variable that tells a child process which test to run a single attempt of
+	attemptTestEnv = "ODIZE_ATTEMPT_TEST"
+	// attemptResultEnv is the environment variable that tells a child process where to write the result of the attempt
+	attemptResultEnv = "ODIZE_ATTEMPT_RESULT"
+)
+
+// attemptTest and attemptResultPath are set when the test binary was started to run a single attempt of a test
+var (
+	attemptTest       = os.Getenv(attemptTestEnv)
+	attemptResultPath = os.Getenv(attemptResultEnv)
+)
+
+var errAttemptNotStarted = errors.New("unable to start attempt")
+
+// attemptResult - Outcome of a single attempt of a test, run in a child process of the test binary
+type attemptResult struct {
+	Ran      bool `json:"ran"`
+	Failed   bool `json:"failed"`
+	Skipped  bool `json:"skipped"`
+	TimedOut bool `json:"timedOut"`
+	// output of the attempt, or of the whole child process if the attempt did not report a result
+	output string
+}
+
+func init() {
+	// processes started by the attempt must run their tests as normal
+	_ = os.Unsetenv(attemptTestEnv)
+	_ = os.Unsetenv(attemptResultEnv)
+}
+
+// runIsolatedAttempt runs a single attempt of the test in a child process of the test binary.
+//
+// Go does not allow a failed *testing.T to recover, so attempts that are allowed to fail run against the child's own *testing.T,
+// with its own context, cleanups, subtests and environment. The attempt's output is returned to be replayed.
+func runIsolatedAttempt(t *testing.T) (attemptResult, error) {
+	t.Helper()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return attemptResult{}, fmt.Errorf("%w: %w", errAttemptNotStarted, err)
+	}
+
+	resultPath := filepath.Join(t.TempDir(), "attempt.json")
+
+	args := []string{"-test.run=" + testNamePattern(t.Name()), "-test.count=1", "-test.v=true"}
+	if deadline, ok := t.Deadline(); ok {
+		args = append(args, "-test.timeout="+max(time.Until(deadline), time.Second).String())
+	}
+
+	// the attempt writes coverage to the same directory, so go test includes it in the coverage of the package
+	if coverDir := flag.Lookup("test.gocoverdir"); coverDir != nil && coverDir.Value.String() != "" {
+		args = append(args, "-test.gocoverdir="+coverDir.Value.String())
+	}
+
+	cmd := exec.CommandContext(t.Context(), executable, args...) //nolint:gosec // re-runs the test binary for a single test
+	cmd.Env = append(os.Environ(), attemptTestEnv+"="+t.Name(), attemptResultEnv+"="+resultPath)
+
+	output, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return attemptResult{}, fmt.Errorf("%w: %w", errAttemptNotStarted, err)
+	}
+
+	result := attemptResult{output: string(output)}
+
+	content, err := os.ReadFile(resultPath)
+	if err != nil {
+		// the child exited before the attempt reported a result, e.g. a panic
+		return result, nil
+	}
+
+	if err := json.Unmarshal(content, &result); err != nil {
+		return result, nil
+	}
+
+	result.output = attemptOutput(string(output), t.Name())
+
+	return result, nil
+}
+
+// runChildAttempt runs the single attempt requested by the parent process, writing the result once the test and its cleanups complete
+func (tg *TestGroup) runChildAttempt(t *testing.T, entry TestRegistryEntry, timeout time.Duration) {
+	t.Helper()
+
+	result := attemptResult{Ran: true}
+
+	// registered first so it runs after every other cleanup
+	t.Cleanup(func() {
+		result.Failed = t.Failed()
+		result.Skipped = t.Skipped()
+
+		if err := writeAttemptResult(attemptResultPath, result); err != nil {
+			t.Errorf("unable to write attempt result: %v", err)
+		}
+	})
+
+	// the testing package runs cleanups before marking a panicking test as failed, so panics are reported as a failure of the attempt
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("test \"%s\" panicked: %v\n%s", t.Name(), r, debug.Stack())
+		}
+	}()
+
+	result.TimedOut = !tg.runAttempt(t, entry, timeout)
+}
+
+// passed checks if the attempt ran and passed
+func (r attemptResult) passed() bool {
+	return r.Ran && !r.Failed && !r.Skipped
+}
+
+// isAttemptChild checks if the test is the single attempt the test binary was started to run
+func isAttemptChild(t *testing.T) bool {
+	return attemptTest != "" && t.Name() == attemptTest
+}
+
+// writeAttemptResult writes the result for the parent process
+func writeAttemptResult(path string, result attemptResult) error {
+	content, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+// testNamePattern returns a -test.run pattern that only matches the test, e.g. ^TestUser$/^should_add$
+func testNamePattern(name string) string {
+	elements := strings.Split(name, "/")
+	for i, element := range elements {
+		elements[i] = "^" + regexp.QuoteMeta(element) + "$"
+	}
+
+	return strings.Join(elements, "/")
+}
+
+// attemptOutput returns the lines of verbose test output written by the test and its subtests
+func attemptOutput(output string, name string) string {
+	var lines []string
+
+	current := ""
+	for _, line := range strings.Split(output, "\n") {
+		if marked, ok := testOutputName(line); ok {
+			current = marked
+		} else if !strings.HasPrefix(line, " ") {
+			// unindented lines are written by the test binary, e.g. the final FAIL
+			current = ""
+		}
+
+		if current == name || strings.HasPrefix(current, name+"/") {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// testOutputName returns the test named by a verbose output marker, e.g. "=== RUN   TestUser" or "--- FAIL: TestUser (0.00s)"
+func testOutputName(line string) (string, bool) {
+	line = strings.TrimLeft(line, " ")
+
+	for _, prefix := range []string{"=== RUN", "=== PAUSE", "=== CONT", "=== NAME"} {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			return strings.TrimSpace(rest), true
+		}
+	}
+
+	for _, prefix := range []string{"--- PASS:", "--- FAIL:", "--- SKIP:"} {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			name, _, _ := strings.Cut(strings.TrimSpace(rest), " (")
+			return name, true
+		}
+	}
+
+	return "", false
+}
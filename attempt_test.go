@@ -0,0 +1,62 @@
+package odize
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTestNamePattern(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should anchor each element of the name", func(t *testing.T) {
+			AssertEqual(t, "^TestUser$/^should_add$", testNamePattern("TestUser/should_add"))
+		}).
+		Test("should escape regex characters", func(t *testing.T) {
+			pattern := testNamePattern("TestUser/should_add_(admin)_1.5")
+
+			AssertEqual(t, `^TestUser$/^should_add_\(admin\)_1\.5$`, pattern)
+
+			_, element, _ := strings.Cut(pattern, "/")
+			AssertTrue(t, regexp.MustCompile(element).MatchString("should_add_(admin)_1.5"))
+			AssertFalse(t, regexp.MustCompile(element).MatchString("should_add_(admin)_105"))
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAttemptOutput(t *testing.T) {
+	output := strings.Join([]string{
+		"=== RUN   TestUser",
+		"=== RUN   TestUser/should_add",
+		"    user_test.go:10: connection refused",
+		"=== RUN   TestUser/should_add/nested",
+		"=== NAME  TestUser/should_add",
+		"    user_test.go:12: retrying",
+		"=== NAME  TestUser",
+		"    user_test.go:20: after group",
+		"--- FAIL: TestUser (0.00s)",
+		"    --- FAIL: TestUser/should_add (0.00s)",
+		"        --- PASS: TestUser/should_add/nested (0.00s)",
+		"FAIL",
+		"exit status 1",
+	}, "\n")
+
+	AssertEqual(t, strings.Join([]string{
+		"=== RUN   TestUser/should_add",
+		"    user_test.go:10: connection refused",
+		"=== RUN   TestUser/should_add/nested",
+		"=== NAME  TestUser/should_add",
+		"    user_test.go:12: retrying",
+		"    --- FAIL: TestUser/should_add (0.00s)",
+		"        --- PASS: TestUser/should_add/nested (0.00s)",
+	}, "\n"), attemptOutput(output, "TestUser/should_add"))
+}
+
+func TestAttemptResultPassed(t *testing.T) {
+	AssertTrue(t, attemptResult{Ran: true}.passed())
+	AssertFalse(t, attemptResult{}.passed())
+	AssertFalse(t, attemptResult{Ran: true, Failed: true}.passed())
+	AssertFalse(t, attemptResult{Ran: true, Skipped: true}.passed())
+}
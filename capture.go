@@ -0,0 +1,277 @@
+package odize
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// LogRecord - Record logged to a logger created by CaptureSlog. Attributes within groups are flattened, with keys joined by a dot.
+type LogRecord struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   []slog.Attr
+}
+
+// LogCapture - Records logged to a logger created by CaptureSlog
+type LogCapture struct {
+	t       testing.TB
+	mu      sync.Mutex
+	records []LogRecord
+}
+
+// captureHandler - slog.Handler that records to a LogCapture
+type captureHandler struct {
+	capture *LogCapture
+	attrs   []slog.Attr
+	groups  []string
+}
+
+// CaptureOutput runs fn, returning everything written to os.Stdout and os.Stderr.
+// os.Stdout and os.Stderr are replaced for the whole process while fn runs, do not use with parallel tests.
+//
+// Example:
+//
+//	stdout, stderr := CaptureOutput(t, func() {
+//		cmd.Execute()
+//	})
+func CaptureOutput(t testing.TB, fn func()) (string, string) {
+	t.Helper()
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	outWriter, outDone, err := capturePipe(stdout)
+	if err != nil {
+		log(t, fmt.Sprintf("unable to capture stdout: %v", err))
+		return "", ""
+	}
+
+	errWriter, errDone, err := capturePipe(stderr)
+	if err != nil {
+		_ = outWriter.Close()
+		log(t, fmt.Sprintf("unable to capture stderr: %v", err))
+		return "", ""
+	}
+
+	originalOut, originalErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outWriter, errWriter
+
+	func() {
+		defer func() {
+			os.Stdout, os.Stderr = originalOut, originalErr
+			_ = outWriter.Close()
+			_ = errWriter.Close()
+		}()
+
+		fn()
+	}()
+
+	<-outDone
+	<-errDone
+
+	return stdout.String(), stderr.String()
+}
+
+// capturePipe creates a pipe, copying everything written to it into buf until the writer is closed
+func capturePipe(buf *bytes.Buffer) (*os.File, <-chan struct{}, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() { _ = reader.Close() }()
+
+		_, _ = io.Copy(buf, reader)
+	}()
+
+	return writer, done, nil
+}
+
+// CaptureSlog returns a logger that records every record at any level, and the capture to assert on.
+//
+// Example:
+//
+//	logger, logs := CaptureSlog(t)
+//	svc := NewService(logger)
+//
+//	svc.Process()
+//	logs.AssertLogged(slog.LevelError, "failed to process", slog.String("id", "1"))
+func CaptureSlog(t testing.TB) (*slog.Logger, *LogCapture) {
+	capture := &LogCapture{t: t}
+
+	return slog.New(&captureHandler{capture: capture}), capture
+}
+
+// Records - Records logged, in the order they were logged
+func (lc *LogCapture) Records() []LogRecord {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return slices.Clone(lc.records)
+}
+
+// AssertLogged checks a record was logged at the level, with a message containing msgContains and each of the attributes.
+// Attributes within groups are matched by their dotted key e.g. slog.String("request.id", "1").
+//
+// Example:
+//
+//	logs.AssertLogged(slog.LevelInfo, "user created", slog.String("id", "1"))
+func (lc *LogCapture) AssertLogged(level slog.Level, msgContains string, attrs ...slog.Attr) {
+	lc.t.Helper()
+
+	records := lc.Records()
+	if slices.ContainsFunc(records, func(r LogRecord) bool { return r.matches(level, msgContains, attrs) }) {
+		return
+	}
+
+	log(lc.t, decorateDiff(formatLogRecord(level, msgContains, attrs), formatLogRecords(records)))
+}
+
+// AssertNotLogged checks no record was logged at the level, with a message containing msgContains and each of the attributes
+//
+// Example:
+//
+//	logs.AssertNotLogged(slog.LevelError, "")
+func (lc *LogCapture) AssertNotLogged(level slog.Level, msgContains string, attrs ...slog.Attr) {
+	lc.t.Helper()
+
+	records := lc.Records()
+	if !slices.ContainsFunc(records, func(r LogRecord) bool { return r.matches(level, msgContains, attrs) }) {
+		return
+	}
+
+	log(lc.t, decorateDiff("no record matching "+formatLogRecord(level, msgContains, attrs), formatLogRecords(records)))
+}
+
+// matches checks the record has the level, a message containing msgContains, and each of the attributes
+func (r LogRecord) matches(level slog.Level, msgContains string, attrs []slog.Attr) bool {
+	if r.Level != level || !strings.Contains(r.Message, msgContains) {
+		return false
+	}
+
+	for _, expected := range attrs {
+		if !slices.ContainsFunc(r.Attrs, func(a slog.Attr) bool { return a.Equal(expected) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Enabled - Record every level
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle - Record the record with its flattened attributes
+func (h *captureHandler) Handle(_ context.Context, record slog.Record) error {
+	attrs := slices.Clone(h.attrs)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = appendFlattened(attrs, h.groups, attr)
+		return true
+	})
+
+	h.capture.mu.Lock()
+	defer h.capture.mu.Unlock()
+
+	h.capture.records = append(h.capture.records, LogRecord{
+		Time:    record.Time,
+		Level:   record.Level,
+		Message: record.Message,
+		Attrs:   attrs,
+	})
+
+	return nil
+}
+
+// WithAttrs - Handler that adds the attributes to each record
+func (h *captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := h.clone()
+	for _, attr := range attrs {
+		next.attrs = appendFlattened(next.attrs, h.groups, attr)
+	}
+
+	return next
+}
+
+// WithGroup - Handler that adds the group to the key of each following attribute
+func (h *captureHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	next := h.clone()
+	next.groups = append(next.groups, name)
+
+	return next
+}
+
+func (h *captureHandler) clone() *captureHandler {
+	return &captureHandler{
+		capture: h.capture,
+		attrs:   slices.Clone(h.attrs),
+		groups:  slices.Clone(h.groups),
+	}
+}
+
+// appendFlattened appends the attribute with its key prefixed by the groups, flattening group attributes
+func appendFlattened(attrs []slog.Attr, groups []string, attr slog.Attr) []slog.Attr {
+	value := attr.Value.Resolve()
+
+	if value.Kind() == slog.KindGroup {
+		nested := groups
+		if attr.Key != "" {
+			nested = append(slices.Clone(groups), attr.Key)
+		}
+
+		for _, child := range value.Group() {
+			attrs = appendFlattened(attrs, nested, child)
+		}
+
+		return attrs
+	}
+
+	if attr.Key == "" {
+		return attrs
+	}
+
+	key := strings.Join(append(slices.Clone(groups), attr.Key), ".")
+
+	return append(attrs, slog.Attr{Key: key, Value: value})
+}
+
+// formatLogRecord formats a record as level, message and attributes e.g. INFO "user created" id=1
+func formatLogRecord(level slog.Level, msg string, attrs []slog.Attr) string {
+	parts := []string{level.String(), fmt.Sprintf("%q", msg)}
+	for _, attr := range attrs {
+		parts = append(parts, attr.String())
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatLogRecords formats each record on its own line
+func formatLogRecords(records []LogRecord) string {
+	if len(records) == 0 {
+		return "no records logged"
+	}
+
+	lines := make([]string, len(records))
+	for i, r := range records {
+		lines[i] = formatLogRecord(r.Level, r.Message, r.Attrs)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,123 @@
+package odize
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCaptureOutput(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should capture stdout and stderr", func(t *testing.T) {
+			stdout, stderr := CaptureOutput(t, func() {
+				_, _ = fmt.Fprintln(os.Stdout, "to stdout")
+				_, _ = fmt.Fprintln(os.Stderr, "to stderr")
+			})
+
+			AssertEqual(t, "to stdout\n", stdout)
+			AssertEqual(t, "to stderr\n", stderr)
+		}).
+		Test("should capture output larger than the pipe buffer", func(t *testing.T) {
+			content := strings.Repeat("a", 1<<20)
+
+			stdout, _ := CaptureOutput(t, func() {
+				_, _ = fmt.Fprint(os.Stdout, content)
+			})
+
+			AssertEqual(t, len(content), len(stdout))
+		}).
+		Test("should restore stdout and stderr", func(t *testing.T) {
+			originalOut, originalErr := os.Stdout, os.Stderr
+
+			CaptureOutput(t, func() {})
+
+			AssertEqual(t, originalOut, os.Stdout)
+			AssertEqual(t, originalErr, os.Stderr)
+		}).
+		Test("should restore stdout and stderr when fn panics", func(t *testing.T) {
+			originalOut := os.Stdout
+
+			func() {
+				defer func() {
+					_ = recover()
+				}()
+
+				CaptureOutput(t, func() {
+					panic("boom")
+				})
+			}()
+
+			AssertEqual(t, originalOut, os.Stdout)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestCaptureSlog(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should record records at every level", func(t *testing.T) {
+			logger, logs := CaptureSlog(t)
+
+			logger.Debug("debug message")
+			logger.Error("error message", slog.String("id", "1"))
+
+			records := logs.Records()
+			AssertEqual(t, 2, len(records))
+			AssertEqual(t, slog.LevelDebug, records[0].Level)
+			AssertEqual(t, "error message", records[1].Message)
+		}).
+		Test("should match level, message and attributes", func(t *testing.T) {
+			logger, logs := CaptureSlog(t)
+
+			logger.Info("user created", slog.String("id", "1"), slog.Int("age", 30))
+
+			logs.AssertLogged(slog.LevelInfo, "created", slog.String("id", "1"))
+			logs.AssertLogged(slog.LevelInfo, "", slog.Int("age", 30), slog.String("id", "1"))
+			logs.AssertNotLogged(slog.LevelError, "")
+			logs.AssertNotLogged(slog.LevelInfo, "user created", slog.String("id", "2"))
+		}).
+		Test("should flatten groups and logger attributes", func(t *testing.T) {
+			logger, logs := CaptureSlog(t)
+
+			logger.With(slog.String("service", "users")).
+				WithGroup("request").
+				Info("handled", slog.String("id", "1"), slog.Group("user", slog.String("name", "John")))
+
+			logs.AssertLogged(slog.LevelInfo, "handled",
+				slog.String("service", "users"),
+				slog.String("request.id", "1"),
+				slog.String("request.user.name", "John"),
+			)
+		}).
+		Test("should fail when no record matches", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				logger, logs := CaptureSlog(c)
+				logger.Info("user created", slog.String("id", "1"))
+
+				logs.AssertLogged(slog.LevelInfo, "user created", slog.String("id", "2"))
+			})
+
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), `INFO "user created" id=2`))
+			AssertTrue(t, strings.Contains(c.String(), `INFO "user created" id=1`))
+		}).
+		Test("should fail when a record matches", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				logger, logs := CaptureSlog(c)
+				logger.Error("failed")
+
+				logs.AssertNotLogged(slog.LevelError, "")
+			})
+
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), `no record matching ERROR ""`))
+		}).
+		Run()
+	AssertNoError(t, err)
+}
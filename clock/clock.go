@@ -0,0 +1,136 @@
+// Package clock provides a Clock interface to inject time into code, with a real implementation and a fake for deterministic tests.
+package clock
+
+import "time"
+
+// Clock - Source of time, timers and tickers. Use Real in production, and Fake in tests.
+type Clock interface {
+	// Now - Current time
+	Now() time.Time
+	// Since - Time elapsed since t
+	Since(t time.Time) time.Duration
+	// Until - Duration until t
+	Until(t time.Time) time.Duration
+	// Sleep - Pause the current goroutine for at least d
+	Sleep(d time.Duration)
+	// After - Wait for d to elapse, then send the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
+	// NewTimer - Create a timer that sends the current time on its channel after d
+	NewTimer(d time.Duration) *Timer
+	// AfterFunc - Wait for d to elapse, then call f
+	AfterFunc(d time.Duration, f func()) *Timer
+	// NewTicker - Create a ticker that sends the current time on its channel every d. Panics if d is not positive.
+	NewTicker(d time.Duration) *Ticker
+}
+
+// Timer - Single event, created by a Clock. Mirrors time.Timer.
+type Timer struct {
+	// Channel the time is sent on when the timer fires, nil for timers created by AfterFunc
+	C     <-chan time.Time
+	timer *time.Timer
+	fake  *waiter
+	clock *Fake
+}
+
+// Ticker - Repeating event, created by a Clock. Mirrors time.Ticker.
+type Ticker struct {
+	// Channel the time is sent on each tick
+	C      <-chan time.Time
+	ticker *time.Ticker
+	fake   *waiter
+	clock  *Fake
+}
+
+// Real - Clock backed by the time package
+type Real struct{}
+
+var (
+	_ Clock = Real{}
+	_ Clock = (*Fake)(nil)
+)
+
+// Now - Current time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Since - Time elapsed since t
+func (Real) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// Until - Duration until t
+func (Real) Until(t time.Time) time.Duration {
+	return time.Until(t)
+}
+
+// Sleep - Pause the current goroutine for at least d
+func (Real) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// After - Wait for d to elapse, then send the current time on the returned channel
+func (Real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTimer - Create a timer that sends the current time on its channel after d
+func (Real) NewTimer(d time.Duration) *Timer {
+	timer := time.NewTimer(d)
+
+	return &Timer{C: timer.C, timer: timer}
+}
+
+// AfterFunc - Wait for d to elapse, then call f in its own goroutine
+func (Real) AfterFunc(d time.Duration, f func()) *Timer {
+	return &Timer{timer: time.AfterFunc(d, f)}
+}
+
+// NewTicker - Create a ticker that sends the current time on its channel every d. Panics if d is not positive.
+func (Real) NewTicker(d time.Duration) *Ticker {
+	ticker := time.NewTicker(d)
+
+	return &Ticker{C: ticker.C, ticker: ticker}
+}
+
+// Stop - Prevent the timer from firing. Returns false if the timer already fired or was stopped.
+func (t *Timer) Stop() bool {
+	if t.timer != nil {
+		return t.timer.Stop()
+	}
+
+	return t.clock.stop(t.fake)
+}
+
+// Reset - Change the timer to fire after d. Returns true if the timer was active.
+func (t *Timer) Reset(d time.Duration) bool {
+	if t.timer != nil {
+		return t.timer.Reset(d)
+	}
+
+	return t.clock.reset(t.fake, d, 0)
+}
+
+// Stop - Turn off the ticker. No more ticks are sent.
+func (t *Ticker) Stop() {
+	if t.ticker != nil {
+		t.ticker.Stop()
+		return
+	}
+
+	t.clock.stop(t.fake)
+}
+
+// Reset - Stop the ticker and reset its period to d. The next tick is sent after d. Panics if d is not positive.
+func (t *Ticker) Reset(d time.Duration) {
+	if t.ticker != nil {
+		t.ticker.Reset(d)
+		return
+	}
+
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+
+	t.clock.reset(t.fake, d, d)
+}
@@ -0,0 +1,61 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-gorilla-au/odize"
+	"github.com/code-gorilla-au/odize/clock"
+)
+
+func TestReal(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	var clk clock.Real
+
+	err := group.
+		Test("should return current time", func(t *testing.T) {
+			before := time.Now()
+			now := clk.Now()
+
+			odize.AssertFalse(t, now.Before(before))
+			odize.AssertTrue(t, clk.Since(before) >= 0)
+			odize.AssertTrue(t, clk.Until(before) <= 0)
+		}).
+		Test("should fire timer", func(t *testing.T) {
+			timer := clk.NewTimer(time.Millisecond)
+			<-timer.C
+
+			odize.AssertFalse(t, timer.Stop())
+		}).
+		Test("should stop timer", func(t *testing.T) {
+			timer := clk.NewTimer(time.Hour)
+
+			odize.AssertTrue(t, timer.Stop())
+			odize.AssertFalse(t, timer.Reset(time.Hour))
+			odize.AssertTrue(t, timer.Stop())
+		}).
+		Test("should call func", func(t *testing.T) {
+			done := make(chan struct{})
+			clk.AfterFunc(time.Millisecond, func() { close(done) })
+
+			<-done
+		}).
+		Test("should tick", func(t *testing.T) {
+			ticker := clk.NewTicker(time.Millisecond)
+			defer ticker.Stop()
+
+			<-ticker.C
+			ticker.Reset(time.Millisecond)
+			<-ticker.C
+		}).
+		Test("should sleep", func(t *testing.T) {
+			start := time.Now()
+			clk.Sleep(time.Millisecond)
+			<-clk.After(time.Millisecond)
+
+			odize.AssertTrue(t, time.Since(start) >= 2*time.Millisecond)
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
@@ -0,0 +1,255 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake - Clock that only moves when told to. Timers, tickers and sleeps fire in order of their deadline as the clock is advanced.
+//
+// Unlike the time package, functions passed to AfterFunc are called on the goroutine advancing the clock,
+// so their effects are visible as soon as Advance or Set returns.
+type Fake struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+// waiter - Pending timer, ticker or sleep
+type waiter struct {
+	when   time.Time
+	period time.Duration
+	ch     chan time.Time
+	fn     func()
+}
+
+// NewFake - Create a fake clock frozen at now
+//
+// Example:
+//
+//	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	cache := NewCache(clk, time.Minute)
+//
+//	clk.Advance(2 * time.Minute)
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.changed = sync.NewCond(&f.mu)
+
+	return f
+}
+
+// Now - Current time of the fake clock
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Since - Time elapsed since t
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// Until - Duration until t
+func (f *Fake) Until(t time.Time) time.Duration {
+	return t.Sub(f.Now())
+}
+
+// Sleep - Pause the current goroutine until the clock is advanced by at least d
+func (f *Fake) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	<-f.NewTimer(d).C
+}
+
+// After - Send the current time on the returned channel once the clock is advanced by d
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C
+}
+
+// NewTimer - Create a timer that sends the current time on its channel once the clock is advanced by d
+func (f *Fake) NewTimer(d time.Duration) *Timer {
+	w := &waiter{ch: make(chan time.Time, 1)}
+	f.schedule(w, d)
+
+	return &Timer{C: w.ch, fake: w, clock: f}
+}
+
+// AfterFunc - Call fn once the clock is advanced by d. If d is not positive, fn is called on the next Advance or Set.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) *Timer {
+	w := &waiter{fn: fn}
+	f.schedule(w, d)
+
+	return &Timer{fake: w, clock: f}
+}
+
+// NewTicker - Create a ticker that sends the current time on its channel each time the clock passes a multiple of d.
+// Panics if d is not positive.
+func (f *Fake) NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	w := &waiter{ch: make(chan time.Time, 1), period: d}
+	f.schedule(w, d)
+
+	return &Ticker{C: w.ch, fake: w, clock: f}
+}
+
+// Advance - Move the clock forward by d, firing each timer and ticker that is due in order of its deadline
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set - Move the clock to t, firing each timer and ticker that is due in order of its deadline.
+// Setting the clock back in time does not fire anything.
+func (f *Fake) Set(t time.Time) {
+	for {
+		f.mu.Lock()
+
+		w := f.next(t)
+		if w == nil {
+			f.now = t
+			f.mu.Unlock()
+
+			return
+		}
+
+		if w.when.After(f.now) {
+			f.now = w.when
+		}
+
+		if w.period > 0 {
+			w.when = w.when.Add(w.period)
+		} else {
+			f.remove(w)
+		}
+
+		now := f.now
+		f.mu.Unlock()
+
+		f.fire(w, now)
+	}
+}
+
+// Reset - Move the clock to t and discard every timer, ticker and sleep without firing them
+func (f *Fake) Reset(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = t
+	f.waiters = nil
+	f.changed.Broadcast()
+}
+
+// Waiters - Number of active timers, tickers and sleeps
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.waiters)
+}
+
+// BlockUntil - Block until there are at least n active timers, tickers and sleeps.
+// Use before Advance to make sure the code under test is waiting on the clock.
+//
+// Example:
+//
+//	go worker.Run(clk)
+//
+//	clk.BlockUntil(1)
+//	clk.Advance(time.Second)
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for len(f.waiters) < n {
+		f.changed.Wait()
+	}
+}
+
+// schedule adds the waiter. Timers with a non-positive duration send the time immediately,
+// functions with a non-positive duration are called on the next Advance or Set.
+func (f *Fake) schedule(w *waiter, d time.Duration) {
+	f.mu.Lock()
+
+	if d <= 0 && w.fn == nil {
+		now := f.now
+		f.mu.Unlock()
+
+		f.fire(w, now)
+
+		return
+	}
+
+	defer f.mu.Unlock()
+
+	w.when = f.now.Add(max(d, 0))
+	f.waiters = append(f.waiters, w)
+	f.changed.Broadcast()
+}
+
+// stop removes the waiter, returning true if it was active
+func (f *Fake) stop(w *waiter) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.remove(w)
+}
+
+// reset reschedules the waiter d from now, returning true if it was active
+func (f *Fake) reset(w *waiter, d time.Duration, period time.Duration) bool {
+	f.mu.Lock()
+	active := f.remove(w)
+	w.period = period
+	f.mu.Unlock()
+
+	f.schedule(w, d)
+
+	return active
+}
+
+// next returns the active waiter with the earliest deadline not after t, in order of creation for equal deadlines
+func (f *Fake) next(t time.Time) *waiter {
+	var next *waiter
+	for _, w := range f.waiters {
+		if w.when.After(t) {
+			continue
+		}
+
+		if next == nil || w.when.Before(next.when) {
+			next = w
+		}
+	}
+
+	return next
+}
+
+// remove removes the waiter, returning true if it was active
+func (f *Fake) remove(w *waiter) bool {
+	for i, existing := range f.waiters {
+		if existing == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// fire calls the waiter's function, or sends the time on its channel, dropping the tick if the previous one was not received
+func (f *Fake) fire(w *waiter, now time.Time) {
+	if w.fn != nil {
+		w.fn()
+		return
+	}
+
+	select {
+	case w.ch <- now:
+	default:
+	}
+}
@@ -0,0 +1,154 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/code-gorilla-au/odize"
+	"github.com/code-gorilla-au/odize/clock"
+)
+
+func TestFake(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var clk *clock.Fake
+
+	group.BeforeEach(func() {
+		clk = clock.NewFake(start)
+	})
+
+	err := group.
+		Test("should only move when advanced", func(t *testing.T) {
+			odize.AssertEqual(t, start, clk.Now())
+
+			clk.Advance(time.Minute)
+			odize.AssertEqual(t, start.Add(time.Minute), clk.Now())
+			odize.AssertEqual(t, time.Minute, clk.Since(start))
+			odize.AssertEqual(t, time.Minute, clk.Until(start.Add(2*time.Minute)))
+		}).
+		Test("should fire timer once its deadline is reached", func(t *testing.T) {
+			timer := clk.NewTimer(time.Second)
+
+			clk.Advance(999 * time.Millisecond)
+			odize.AssertEqual(t, 0, len(timer.C))
+
+			clk.Advance(time.Millisecond)
+			odize.AssertEqual(t, start.Add(time.Second), <-timer.C)
+			odize.AssertEqual(t, 0, clk.Waiters())
+		}).
+		Test("should send immediately for non positive durations", func(t *testing.T) {
+			odize.AssertEqual(t, start, <-clk.After(0))
+		}).
+		Test("should stop and reset timer", func(t *testing.T) {
+			timer := clk.NewTimer(time.Second)
+
+			odize.AssertTrue(t, timer.Stop())
+			odize.AssertFalse(t, timer.Stop())
+
+			clk.Advance(time.Second)
+			odize.AssertEqual(t, 0, len(timer.C))
+
+			odize.AssertFalse(t, timer.Reset(time.Second))
+			clk.Advance(time.Second)
+			odize.AssertEqual(t, start.Add(2*time.Second), <-timer.C)
+		}).
+		Test("should fire funcs in order of deadline at their deadline", func(t *testing.T) {
+			var fired []string
+			var firedAt []time.Time
+
+			record := func(name string) func() {
+				return func() {
+					fired = append(fired, name)
+					firedAt = append(firedAt, clk.Now())
+				}
+			}
+
+			clk.AfterFunc(3*time.Second, record("third"))
+			clk.AfterFunc(time.Second, record("first"))
+			clk.AfterFunc(2*time.Second, record("second"))
+			clk.AfterFunc(0, record("now"))
+
+			clk.Advance(5 * time.Second)
+
+			odize.AssertEqual(t, []string{"now", "first", "second", "third"}, fired)
+			odize.AssertEqual(t, []time.Time{start, start.Add(time.Second), start.Add(2 * time.Second), start.Add(3 * time.Second)}, firedAt)
+			odize.AssertEqual(t, start.Add(5*time.Second), clk.Now())
+		}).
+		Test("should fire timers scheduled by funcs within the same advance", func(t *testing.T) {
+			fired := 0
+			clk.AfterFunc(time.Second, func() {
+				clk.AfterFunc(time.Second, func() {
+					fired++
+				})
+			})
+
+			clk.Advance(2 * time.Second)
+			odize.AssertEqual(t, 1, fired)
+		}).
+		Test("should tick on each period", func(t *testing.T) {
+			ticks := 0
+			ticker := clk.NewTicker(time.Second)
+			clk.AfterFunc(0, func() {})
+
+			for range 3 {
+				clk.Advance(time.Second)
+				<-ticker.C
+				ticks++
+			}
+
+			ticker.Reset(time.Minute)
+			clk.Advance(time.Second)
+			odize.AssertEqual(t, 0, len(ticker.C))
+
+			ticker.Stop()
+			clk.Advance(time.Hour)
+			odize.AssertEqual(t, 0, len(ticker.C))
+			odize.AssertEqual(t, 3, ticks)
+		}).
+		Test("should drop ticks that are not received", func(t *testing.T) {
+			ticker := clk.NewTicker(time.Second)
+
+			clk.Advance(5 * time.Second)
+
+			odize.AssertEqual(t, start.Add(time.Second), <-ticker.C)
+			odize.AssertEqual(t, 0, len(ticker.C))
+		}).
+		Test("should not fire when set back in time", func(t *testing.T) {
+			fired := false
+			clk.AfterFunc(time.Second, func() { fired = true })
+
+			clk.Set(start.Add(-time.Hour))
+			odize.AssertEqual(t, start.Add(-time.Hour), clk.Now())
+			odize.AssertFalse(t, fired)
+
+			clk.Set(start.Add(time.Second))
+			odize.AssertTrue(t, fired)
+		}).
+		Test("should wake sleeping goroutine", func(t *testing.T) {
+			done := make(chan time.Time)
+			go func() {
+				clk.Sleep(time.Minute)
+				done <- clk.Now()
+			}()
+
+			clk.BlockUntil(1)
+			clk.Advance(time.Minute)
+
+			odize.AssertEqual(t, start.Add(time.Minute), <-done)
+		}).
+		Test("should discard waiters on reset", func(t *testing.T) {
+			fired := false
+			clk.AfterFunc(time.Second, func() { fired = true })
+			clk.Advance(time.Millisecond)
+
+			clk.Reset(start)
+			clk.Advance(time.Hour)
+
+			odize.AssertFalse(t, fired)
+			odize.AssertEqual(t, 0, clk.Waiters())
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
@@ -0,0 +1,17 @@
+// Command odize lists and runs odize tests.
+//
+//	go install github.com/code-gorilla-au/odize/cmd/odize@latest
+//
+//	odize list --tags unit ./...
+//	odize run --tags unit --shard 1/4 ./...
+package main
+
+import (
+	"os"
+
+	"github.com/code-gorilla-au/odize/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], cli.DefaultIO()))
+}
@@ -0,0 +1,360 @@
+package odize
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// AssertContains checks the collection contains the element.
+// Slices, arrays and channels are checked for an equal element, maps for a key, and strings for a substring.
+// Channels are received from until empty or closed, consuming the buffered elements.
+//
+// Example:
+//
+//	AssertContains(t, []string{"a", "b"}, "a")
+func AssertContains(t testing.TB, collection any, element any) {
+	t.Helper()
+
+	collection = receiveElements(collection)
+
+	found, ok := containsElement(collection, element)
+	if !ok {
+		log(t, fmt.Sprintf("unable to check if %T contains %T", collection, element))
+		return
+	}
+
+	if !found {
+		log(t, fmt.Sprintf("%v does not contain %v", formatValue(collection), formatValue(element))+decorateDiff(fmt.Sprintf("contains %v", formatValue(element)), formatValue(collection)))
+	}
+}
+
+// AssertNotContains checks the collection does not contain the element.
+// Slices, arrays and channels are checked for an equal element, maps for a key, and strings for a substring.
+// Channels are received from until empty or closed, consuming the buffered elements.
+//
+// Example:
+//
+//	AssertNotContains(t, []string{"a", "b"}, "c")
+func AssertNotContains(t testing.TB, collection any, element any) {
+	t.Helper()
+
+	collection = receiveElements(collection)
+
+	found, ok := containsElement(collection, element)
+	if !ok {
+		log(t, fmt.Sprintf("unable to check if %T contains %T", collection, element))
+		return
+	}
+
+	if found {
+		log(t, fmt.Sprintf("%v contains %v", formatValue(collection), formatValue(element))+decorateDiff(fmt.Sprintf("does not contain %v", formatValue(element)), formatValue(collection)))
+	}
+}
+
+// AssertLen checks the length of a slice, array, map, string or channel. The length of a channel is the number of buffered elements.
+//
+// Example:
+//
+//	AssertLen(t, users, 2)
+func AssertLen(t testing.TB, collection any, length int) {
+	t.Helper()
+
+	actual, ok := collectionLen(collection)
+	if !ok {
+		log(t, fmt.Sprintf("unable to get the length of %T", collection))
+		return
+	}
+
+	if actual != length {
+		log(t, fmt.Sprintf("%v has length %d", formatValue(collection), actual)+decorateDiff(length, actual))
+	}
+}
+
+// AssertEmpty checks a slice, array, map, string or channel has a length of 0. Nil values are empty.
+//
+// Example:
+//
+//	AssertEmpty(t, errs)
+func AssertEmpty(t testing.TB, collection any) {
+	t.Helper()
+
+	if collection == nil {
+		return
+	}
+
+	actual, ok := collectionLen(collection)
+	if !ok {
+		log(t, fmt.Sprintf("unable to get the length of %T", collection))
+		return
+	}
+
+	if actual != 0 {
+		log(t, fmt.Sprintf("%v has length %d", formatValue(collection), actual)+decorateDiff("empty", formatValue(collection)))
+	}
+}
+
+// AssertNotEmpty checks a slice, array, map, string or channel has a length greater than 0
+//
+// Example:
+//
+//	AssertNotEmpty(t, users)
+func AssertNotEmpty(t testing.TB, collection any) {
+	t.Helper()
+
+	actual, ok := collectionLen(collection)
+	if !ok && collection != nil {
+		log(t, fmt.Sprintf("unable to get the length of %T", collection))
+		return
+	}
+
+	if actual == 0 {
+		log(t, decorateDiff("not empty", formatValue(collection)))
+	}
+}
+
+// AssertElementsMatch checks two slices, arrays or channels contain equal elements, ignoring order.
+// Duplicates must appear the same number of times. Failures list the missing and extra elements.
+// Channels are received from until empty or closed, consuming the buffered elements.
+//
+// Example:
+//
+//	AssertElementsMatch(t, []int{1, 2, 3}, []int{3, 1, 2})
+func AssertElementsMatch(t testing.TB, expected any, actual any) {
+	t.Helper()
+
+	expected, actual = receiveElements(expected), receiveElements(actual)
+
+	expectedElements, ok := sliceElements(expected)
+	if !ok {
+		log(t, fmt.Sprintf("expected must be a slice, array or channel, got %T", expected))
+		return
+	}
+
+	actualElements, ok := sliceElements(actual)
+	if !ok {
+		log(t, fmt.Sprintf("actual must be a slice, array or channel, got %T", actual))
+		return
+	}
+
+	missing, extra := diffElements(expectedElements, actualElements)
+	if len(missing) > 0 || len(extra) > 0 {
+		log(t, fmt.Sprintf("elements do not match\nmissing: %v\nextra: %v", formatValue(missing), formatValue(extra))+decorateDiff(formatValue(expected), formatValue(actual)))
+	}
+}
+
+// AssertSubset checks every element of subset is in list, ignoring order.
+// For maps, every key of subset must be in list with an equal value. Channels are received from until empty or closed, consuming the buffered elements.
+//
+// Example:
+//
+//	AssertSubset(t, []string{"a", "b", "c"}, []string{"c", "a"})
+func AssertSubset(t testing.TB, list any, subset any) {
+	t.Helper()
+
+	list, subset = receiveElements(list), receiveElements(subset)
+
+	missing, ok := missingFromList(list, subset)
+	if !ok {
+		log(t, fmt.Sprintf("unable to check if %T is a subset of %T", subset, list))
+		return
+	}
+
+	if len(missing) > 0 {
+		log(t, fmt.Sprintf("%v is not a subset\nmissing: [%s]", formatValue(subset), strings.Join(missing, " "))+decorateDiff(fmt.Sprintf("contains %v", formatValue(subset)), formatValue(list)))
+	}
+}
+
+// AssertMapHasKey checks the map has the key
+//
+// Example:
+//
+//	AssertMapHasKey(t, headers, "Content-Type")
+func AssertMapHasKey(t testing.TB, m any, key any) {
+	t.Helper()
+
+	value := reflect.ValueOf(m)
+	if value.Kind() != reflect.Map {
+		log(t, fmt.Sprintf("expected a map, got %T", m))
+		return
+	}
+
+	if found, _ := containsElement(m, key); !found {
+		log(t, fmt.Sprintf("map does not have key %v", formatValue(key))+decorateDiff(formatValue(key), formatValue(mapKeys(value))))
+	}
+}
+
+// collectionLen returns the length of a slice, array, map, string or channel
+func collectionLen(collection any) (int, bool) {
+	value := reflect.ValueOf(collection)
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return value.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+// containsElement checks if a slice or array has an equal element, a map has the key, or a string has the substring.
+// Returns false if the collection is not supported.
+func containsElement(collection any, element any) (bool, bool) {
+	value := reflect.ValueOf(collection)
+
+	switch value.Kind() {
+	case reflect.String:
+		substring, ok := element.(string)
+		return ok && strings.Contains(value.String(), substring), ok
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			if isEqual(key.Interface(), element) {
+				return true, true
+			}
+		}
+
+		return false, true
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			if isEqual(value.Index(i).Interface(), element) {
+				return true, true
+			}
+		}
+
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// receiveElements receives from a channel until it is empty or closed, returning a slice of the elements.
+// Other collections, and channels that cannot be received from, are returned unchanged.
+func receiveElements(collection any) any {
+	value := reflect.ValueOf(collection)
+	if value.Kind() != reflect.Chan || value.Type().ChanDir()&reflect.RecvDir == 0 {
+		return collection
+	}
+
+	elements := reflect.MakeSlice(reflect.SliceOf(value.Type().Elem()), 0, value.Len())
+	for {
+		element, ok := value.TryRecv()
+		if !ok {
+			return elements.Interface()
+		}
+
+		elements = reflect.Append(elements, element)
+	}
+}
+
+// sliceElements returns the elements of a slice or array
+func sliceElements(collection any) ([]any, bool) {
+	value := reflect.ValueOf(collection)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	elements := make([]any, value.Len())
+	for i := range elements {
+		elements[i] = value.Index(i).Interface()
+	}
+
+	return elements, true
+}
+
+// diffElements returns the elements of expected that are not in actual, and the elements of actual that are not in expected,
+// counting duplicates
+func diffElements(expected []any, actual []any) ([]any, []any) {
+	matched := make([]bool, len(actual))
+
+	var missing []any
+	for _, element := range expected {
+		found := false
+		for i, candidate := range actual {
+			if !matched[i] && isEqual(element, candidate) {
+				matched[i] = true
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, element)
+		}
+	}
+
+	var extra []any
+	for i, candidate := range actual {
+		if !matched[i] {
+			extra = append(extra, candidate)
+		}
+	}
+
+	return missing, extra
+}
+
+// missingFromList returns the formatted elements of subset that are not in list.
+// For maps, returns the entries of subset whose key is not in list with an equal value.
+func missingFromList(list any, subset any) ([]string, bool) {
+	listValue, subsetValue := reflect.ValueOf(list), reflect.ValueOf(subset)
+
+	if listValue.Kind() == reflect.Map && subsetValue.Kind() == reflect.Map {
+		var missing []string
+		for _, key := range subsetValue.MapKeys() {
+			listEntry := listValue.MapIndex(key)
+			subsetEntry := subsetValue.MapIndex(key)
+
+			if !listEntry.IsValid() || !isEqual(listEntry.Interface(), subsetEntry.Interface()) {
+				missing = append(missing, fmt.Sprintf("%v: %v", formatValue(key.Interface()), formatValue(subsetEntry.Interface())))
+			}
+		}
+
+		return missing, true
+	}
+
+	listElements, ok := sliceElements(list)
+	if !ok {
+		return nil, false
+	}
+
+	subsetElements, ok := sliceElements(subset)
+	if !ok {
+		return nil, false
+	}
+
+	missingElements, _ := diffElements(subsetElements, listElements)
+
+	missing := make([]string, len(missingElements))
+	for i, element := range missingElements {
+		missing[i] = formatValue(element)
+	}
+
+	return missing, true
+}
+
+// mapKeys returns the keys of a map
+func mapKeys(m reflect.Value) []any {
+	keys := make([]any, 0, m.Len())
+	for _, key := range m.MapKeys() {
+		keys = append(keys, key.Interface())
+	}
+
+	return keys
+}
+
+// formatValue formats a value for a failure message, quoting strings
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, element := range v {
+			parts[i] = formatValue(element)
+		}
+
+		return "[" + strings.Join(parts, " ") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,203 @@
+package odize
+
+import (
+	"strings"
+	"testing"
+)
+
+// assertFails runs the assertion against a Collect, checking it fails with a message containing each of contains
+func assertFails(t *testing.T, assertion func(c *Collect), contains ...string) {
+	t.Helper()
+
+	c := runCollectAttempt(t, assertion)
+	AssertTrue(t, c.Failed())
+
+	for _, substring := range contains {
+		if !strings.Contains(c.String(), substring) {
+			t.Errorf("expected failure message to contain %q, got:\n%s", substring, c.String())
+		}
+	}
+}
+
+func TestAssertContains(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when collection contains element", func(t *testing.T) {
+			AssertContains(t, []string{"a", "b"}, "b")
+			AssertContains(t, [2]int{1, 2}, 2)
+			AssertContains(t, map[string]int{"a": 1}, "a")
+			AssertContains(t, "hello world", "world")
+		}).
+		Test("should fail when collection does not contain element", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertContains(c, []string{"a", "b"}, "c")
+			}, `does not contain "c"`)
+		}).
+		Test("should receive from buffered and closed channels", func(t *testing.T) {
+			buffered := make(chan int, 3)
+			buffered <- 1
+			buffered <- 2
+			AssertContains(t, buffered, 2)
+			AssertEqual(t, 0, len(buffered))
+
+			closed := make(chan string, 2)
+			closed <- "a"
+			close(closed)
+			AssertNotContains(t, closed, "b")
+		}).
+		Test("should list received elements when channel does not contain element", func(t *testing.T) {
+			ch := make(chan int, 2)
+			ch <- 1
+
+			assertFails(t, func(c *Collect) {
+				AssertContains(c, ch, 3)
+			}, "[1] does not contain 3")
+		}).
+		Test("should fail for unsupported collections", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertContains(c, 1, 1)
+			}, "unable to check if int contains int")
+			assertFails(t, func(c *Collect) {
+				AssertContains(c, make(chan<- int), 1)
+			}, "unable to check if chan<- int contains int")
+		}).
+		Test("should pass when collection does not contain element", func(t *testing.T) {
+			AssertNotContains(t, []string{"a", "b"}, "c")
+			AssertNotContains(t, map[string]int{"a": 1}, "b")
+			AssertNotContains(t, "hello", "world")
+		}).
+		Test("should fail when collection contains element", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertNotContains(c, "hello", "ell")
+			}, `"hello" contains "ell"`)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertLen(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should check length", func(t *testing.T) {
+			ch := make(chan int, 2)
+			ch <- 1
+
+			AssertLen(t, []int{1, 2}, 2)
+			AssertLen(t, map[int]int{1: 1}, 1)
+			AssertLen(t, "abc", 3)
+			AssertLen(t, ch, 1)
+		}).
+		Test("should fail when length is different", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertLen(c, []int{1, 2}, 3)
+			}, "[1 2] has length 2")
+		}).
+		Test("should check empty", func(t *testing.T) {
+			var nilSlice []int
+
+			AssertEmpty(t, nil)
+			AssertEmpty(t, nilSlice)
+			AssertEmpty(t, "")
+			AssertEmpty(t, map[string]int{})
+			AssertEmpty(t, make(chan int, 1))
+		}).
+		Test("should fail when not empty", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertEmpty(c, []int{1})
+			}, "[1] has length 1")
+		}).
+		Test("should check not empty", func(t *testing.T) {
+			AssertNotEmpty(t, []int{1})
+			AssertNotEmpty(t, "a")
+		}).
+		Test("should fail when empty", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertNotEmpty(c, nil)
+			}, "not empty")
+			assertFails(t, func(c *Collect) {
+				AssertNotEmpty(c, map[string]int{})
+			}, "not empty")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertElementsMatch(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when elements match in any order", func(t *testing.T) {
+			AssertElementsMatch(t, []int{1, 2, 2, 3}, []int{2, 3, 1, 2})
+			AssertElementsMatch(t, []string{}, [0]string{})
+		}).
+		Test("should match elements received from a channel", func(t *testing.T) {
+			ch := make(chan int, 3)
+			ch <- 3
+			ch <- 1
+			close(ch)
+
+			AssertElementsMatch(t, []int{1, 3}, ch)
+		}).
+		Test("should list missing and extra elements", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertElementsMatch(c, []string{"a", "b", "b"}, []string{"b", "c"})
+			}, `missing: ["a" "b"]`, `extra: ["c"]`)
+		}).
+		Test("should fail when not a slice", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertElementsMatch(c, []int{1}, map[int]int{})
+			}, "actual must be a slice, array or channel, got map[int]int")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertSubset(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when subset", func(t *testing.T) {
+			AssertSubset(t, []string{"a", "b", "c"}, []string{"c", "a"})
+			AssertSubset(t, map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2})
+		}).
+		Test("should list missing elements", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertSubset(c, []string{"a", "b"}, []string{"a", "d"})
+			}, `missing: ["d"]`)
+		}).
+		Test("should list missing map entries", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertSubset(c, map[string]int{"a": 1}, map[string]int{"a": 2})
+			}, `missing: ["a": 2]`)
+		}).
+		Test("should fail for mismatched collections", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertSubset(c, map[string]int{"a": 1}, []string{"a"})
+			}, "unable to check if []string is a subset of map[string]int")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertMapHasKey(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when map has key", func(t *testing.T) {
+			AssertMapHasKey(t, map[string]int{"a": 1}, "a")
+		}).
+		Test("should fail when map does not have key", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertMapHasKey(c, map[string]int{"a": 1}, "b")
+			}, `map does not have key "b"`)
+		}).
+		Test("should fail when not a map", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertMapHasKey(c, []string{"a"}, "a")
+			}, "expected a map, got []string")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
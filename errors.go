@@ -4,6 +4,10 @@ import "errors"
 
 var (
 	ErrTestAlreadyExists = errors.New("test already exists")
+	ErrTestFlaky         = errors.New("test is flaky")
+	ErrInvalidShuffle    = errors.New("invalid shuffle value, expected \"on\", \"off\" or a seed")
+	ErrInvalidShard      = errors.New("invalid shard value, expected \"index/total\" e.g. \"1/4\"")
+	ErrInvalidFilter     = errors.New("invalid filter value")
 )
 
 // Error - return error string
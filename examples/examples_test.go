@@ -1,6 +1,8 @@
 package examples
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/code-gorilla-au/odize"
@@ -201,3 +203,66 @@ func TestRunOnlyOneWithinGroup(t *testing.T) {
 
 	odize.AssertNoError(t, err)
 }
+
+func TestFixtureExample(t *testing.T) {
+	// will always run
+	group := odize.NewGroup(t, nil)
+
+	type UserEntity struct {
+		Name string
+		Age  int
+	}
+
+	// created fresh for each test that uses it, no shared mutable state
+	user := odize.Fixture(group, func(t testing.TB) (*UserEntity, func()) {
+		return &UserEntity{Name: "John", Age: 2}, nil
+	})
+
+	err := group.
+		Test("user age should equal 2", func(t *testing.T) {
+			odize.AssertEqual(t, 2, user.Get(t).Age)
+		}).
+		Test("user age should equal 3 after birthday", func(t *testing.T) {
+			u := user.Get(t)
+			u.Age++
+			odize.AssertEqual(t, 3, u.Age)
+		}).
+		Test("user age should equal 2 in the next test", func(t *testing.T) {
+			odize.AssertEqual(t, 2, user.Get(t).Age)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestMockExample(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	var notifier *MockNotifier
+
+	group.BeforeEach(func() {
+		notifier = NewMockNotifier()
+	})
+
+	err := group.
+		Test("should welcome each user", func(t *testing.T) {
+			notifier.On("Notify", odize.Any(), odize.AnyOfType[string](), "welcome").Return(nil).Times(2)
+
+			err := Welcome(context.Background(), notifier, "user-1", "user-2")
+			odize.AssertNoError(t, err)
+
+			notifier.Verify(t)
+			odize.AssertCalledWith(t, notifier.Method("Notify"), odize.Any(), "user-2", "welcome")
+		}).
+		Test("should stop at the first error", func(t *testing.T) {
+			notifier.On("Notify", odize.Any(), "user-1", "welcome").Return(errors.New("unavailable"))
+
+			err := Welcome(context.Background(), notifier, "user-1", "user-2")
+			odize.AssertError(t, err)
+
+			notifier.Verify(t)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
@@ -0,0 +1,21 @@
+package examples
+
+import "context"
+
+//go:generate go run ../cmd/odize mockgen -out notifier_mock_test.go Notifier
+
+// Notifier - Send notifications to users, mocked in the mock example
+type Notifier interface {
+	Notify(ctx context.Context, userID string, message string) error
+}
+
+// Welcome - Send a welcome message to each user, stopping at the first error
+func Welcome(ctx context.Context, notifier Notifier, userIDs ...string) error {
+	for _, id := range userIDs {
+		if err := notifier.Notify(ctx, id, "welcome"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
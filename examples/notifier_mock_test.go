@@ -0,0 +1,56 @@
+// Code generated by odize mockgen. DO NOT EDIT.
+
+package examples
+
+import (
+	"context"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+// MockNotifier - Mock of Notifier, generated by odize mockgen
+type MockNotifier struct {
+	mock *odize.Mock
+}
+
+var _ Notifier = (*MockNotifier)(nil)
+
+// NewMockNotifier - Create a MockNotifier
+func NewMockNotifier() *MockNotifier {
+	return &MockNotifier{mock: odize.NewMock()}
+}
+
+// On - Expect a call to the method with the arguments, see odize.Mock.On
+func (m *MockNotifier) On(method string, args ...any) *odize.Expectation {
+	return m.mock.On(method, args...)
+}
+
+// Verify - Fail the test if an expectation was not met, see odize.Mock.Verify
+func (m *MockNotifier) Verify(t testing.TB) {
+	t.Helper()
+
+	m.mock.Verify(t)
+}
+
+// Method - Calls to a single method, used with the spy assertions
+func (m *MockNotifier) Method(method string) *odize.MockMethod {
+	return m.mock.Method(method)
+}
+
+// CallHistory - Calls made to every method of the mock, in the order they were made
+func (m *MockNotifier) CallHistory() []odize.SpyCall {
+	return m.mock.CallHistory()
+}
+
+// Reset - Clear the recorded calls, keeping the expectations
+func (m *MockNotifier) Reset() {
+	m.mock.Reset()
+}
+
+// Notify - Mock of Notifier.Notify
+func (m *MockNotifier) Notify(ctx context.Context, userID string, message string) error {
+	results := m.mock.Called("Notify", ctx, userID, message)
+
+	return odize.MockResult[error](results, 0)
+}
@@ -0,0 +1,42 @@
+package odize
+
+import (
+	"time"
+
+	"github.com/code-gorilla-au/odize/clock"
+)
+
+// fakeClock - Fake clock registered on a group, frozen at start for each test
+type fakeClock struct {
+	clock *clock.Fake
+	start time.Time
+}
+
+// FakeClock - Create a fake clock frozen at start for each test.
+// The clock is reset to start before each test's BeforeEach hook, and after each test's AfterEach hook,
+// discarding any pending timers and tickers.
+//
+// Example:
+//
+//	clk := group.FakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//
+//	group.Test("should expire entries", func(t *testing.T) {
+//		cache := NewCache(clk, time.Minute)
+//		cache.Set("key", "value")
+//
+//		clk.Advance(2 * time.Minute)
+//		AssertFalse(t, cache.Has("key"))
+//	})
+func (tg *TestGroup) FakeClock(start time.Time) *clock.Fake {
+	fake := clock.NewFake(start)
+	tg.fakeClocks = append(tg.fakeClocks, fakeClock{clock: fake, start: start})
+
+	return fake
+}
+
+// resetFakeClocks resets each fake clock registered on the group to its start time
+func (tg *TestGroup) resetFakeClocks() {
+	for _, fc := range tg.fakeClocks {
+		fc.clock.Reset(fc.start)
+	}
+}
@@ -0,0 +1,38 @@
+package odize
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := group.FakeClock(start)
+
+	var nowInBeforeEach time.Time
+	fired := false
+
+	group.BeforeEach(func() {
+		nowInBeforeEach = clk.Now()
+	})
+
+	err := group.
+		Test("should freeze time at start", func(t *testing.T) {
+			AssertEqual(t, start, nowInBeforeEach)
+
+			clk.AfterFunc(time.Hour, func() { fired = true })
+			clk.Advance(time.Minute)
+			AssertEqual(t, start.Add(time.Minute), clk.Now())
+		}).
+		Test("should reset time and discard timers between tests", func(t *testing.T) {
+			AssertEqual(t, start, nowInBeforeEach)
+			AssertEqual(t, 0, clk.Waiters())
+
+			clk.Advance(time.Hour)
+			AssertFalse(t, fired)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
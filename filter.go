@@ -0,0 +1,76 @@
+package odize
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// filterNamedTests skips entries whose name does not match ODIZE_FILTER.
+// The filter is matched against both the test name, and the full name including the group e.g. "TestUser/should add user".
+func (tg *TestGroup) filterNamedTests(entries []TestRegistryEntry) ([]TestRegistryEntry, error) {
+	tg.t.Helper()
+
+	pattern, enabled, err := parseNameFilter(tg.envFilter)
+	if err != nil || !enabled {
+		return entries, err
+	}
+
+	matched := 0
+	filtered := make([]TestRegistryEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		if !pattern.MatchString(entry.name) && !pattern.MatchString(tg.t.Name()+"/"+entry.name) {
+			filtered = append(filtered, skippedEntry(entry.name, fmt.Sprintf("does not match %s=%s", ODIZE_FILTER, tg.envFilter)))
+			continue
+		}
+
+		matched++
+		filtered = append(filtered, entry)
+	}
+
+	suite.recordFilter(tg.envFilter, len(entries), matched)
+
+	return filtered, nil
+}
+
+// parseNameFilter parses a filter, either a regex wrapped in slashes or a glob
+func parseNameFilter(value string) (*regexp.Regexp, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, false, nil
+	}
+
+	expression := globToRegexp(value)
+	if len(value) > 1 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		expression = value[1 : len(value)-1]
+	}
+
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %s=%s: %w", ErrInvalidFilter, ODIZE_FILTER, value, err)
+	}
+
+	return pattern, true, nil
+}
+
+// globToRegexp converts a glob to an anchored regex, where "*" matches any characters and "?" matches a single character
+func globToRegexp(glob string) string {
+	buf := new(strings.Builder)
+	buf.WriteString("^")
+
+	for _, char := range glob {
+		switch char {
+		case '*':
+			buf.WriteString(".*")
+		case '?':
+			buf.WriteString(".")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	buf.WriteString("$")
+
+	return buf.String()
+}
@@ -0,0 +1,91 @@
+package odize
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseNameFilter(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should be disabled when empty", func(t *testing.T) {
+			_, enabled, err := parseNameFilter("")
+			AssertNoError(t, err)
+			AssertFalse(t, enabled)
+		}).
+		Test("should match glob with spaces", func(t *testing.T) {
+			pattern, enabled, err := parseNameFilter("should * user")
+			AssertNoError(t, err)
+			AssertTrue(t, enabled)
+			AssertTrue(t, pattern.MatchString("should add user"))
+			AssertFalse(t, pattern.MatchString("should add user twice"))
+		}).
+		Test("should escape regex characters in glob", func(t *testing.T) {
+			pattern, _, err := parseNameFilter("sum (a+b)?")
+			AssertNoError(t, err)
+			AssertTrue(t, pattern.MatchString("sum (a+b)!"))
+			AssertFalse(t, pattern.MatchString("sum aab"))
+		}).
+		Test("should use regex wrapped in slashes", func(t *testing.T) {
+			pattern, _, err := parseNameFilter("/^should (add|remove)/")
+			AssertNoError(t, err)
+			AssertTrue(t, pattern.MatchString("should remove user"))
+			AssertFalse(t, pattern.MatchString("should update user"))
+		}).
+		Test("should error on invalid regex", func(t *testing.T) {
+			_, _, err := parseNameFilter("/(/")
+			AssertTrue(t, errors.Is(err, ErrInvalidFilter))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestFilterShouldOnlyRunMatchingTests(t *testing.T) {
+	t.Setenv(ODIZE_FILTER, "*user*")
+
+	var ran []string
+	tg := NewGroup(t, nil)
+	err := tg.
+		Test("should add user", func(t *testing.T) {
+			ran = append(ran, "add")
+		}).
+		Test("should add order", func(t *testing.T) {
+			ran = append(ran, "order")
+		}).
+		Test("should remove user", func(t *testing.T) {
+			ran = append(ran, "remove")
+		}).
+		Run()
+
+	AssertNoError(t, err)
+	AssertEqual(t, []string{"add", "remove"}, ran)
+}
+
+func TestFilterShouldMatchFullName(t *testing.T) {
+	t.Setenv(ODIZE_FILTER, "/^TestFilterShouldMatchFullName/should/")
+
+	ran := 0
+	tg := NewGroup(t, nil)
+	err := tg.
+		Test("should run", func(t *testing.T) {
+			ran++
+		}).
+		Run()
+
+	AssertNoError(t, err)
+	AssertEqual(t, 1, ran)
+}
+
+func TestSuiteReportFilterWarning(t *testing.T) {
+	state := newSuiteState()
+	state.recordFilter("*nothing*", 4, 0)
+
+	buf := new(bytes.Buffer)
+	state.report(buf)
+
+	AssertTrue(t, strings.Contains(buf.String(), "ODIZE_FILTER=*nothing* did not match any of the 4 tests"))
+}
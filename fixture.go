@@ -0,0 +1,122 @@
+package odize
+
+import (
+	"sync"
+	"testing"
+)
+
+// FixtureScope - Lifetime of a fixture value
+type FixtureScope int
+
+const (
+	// ScopeTest - Fixture is created once per test, and torn down when the test completes
+	ScopeTest FixtureScope = iota
+	// ScopeGroup - Fixture is created once per group, and torn down when the group completes
+	ScopeGroup
+)
+
+// FixtureSetup - Create a fixture value, returning an optional teardown function
+type FixtureSetup[T any] = func(t testing.TB) (T, func())
+
+// FixtureOpts - Fixture options
+type FixtureOpts = func(*fixtureConfig)
+
+type fixtureConfig struct {
+	scope FixtureScope
+}
+
+// TestFixture - Declared fixture, lazily created when a test calls Get
+type TestFixture[T any] struct {
+	group  *TestGroup
+	setup  FixtureSetup[T]
+	scope  FixtureScope
+	mu     sync.Mutex
+	values map[testing.TB]T
+}
+
+// Fixture - Declare a fixture on the group.
+// The fixture is only created when a test calls Get, and is torn down in reverse order of creation.
+// Fixtures may depend on other fixtures by calling Get within setup.
+//
+// Example:
+//
+//	db := odize.Fixture(group, func(t testing.TB) (*DB, func()) {
+//		conn := openDB(t)
+//		return conn, func() { conn.Close() }
+//	})
+//
+//	group.Test("should insert", func(t *testing.T) {
+//		conn := db.Get(t)
+//	})
+func Fixture[T any](tg *TestGroup, setup FixtureSetup[T], options ...FixtureOpts) *TestFixture[T] {
+	config := fixtureConfig{}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	return &TestFixture[T]{
+		group:  tg,
+		setup:  setup,
+		scope:  config.scope,
+		values: map[testing.TB]T{},
+	}
+}
+
+// GroupScoped - Create the fixture once per group instead of once per test.
+// Group scoped fixtures should only depend on other group scoped fixtures.
+func GroupScoped() FixtureOpts {
+	return func(fc *fixtureConfig) {
+		fc.scope = ScopeGroup
+	}
+}
+
+// Get - Get the fixture value for the test, creating it on first use
+func (f *TestFixture[T]) Get(t testing.TB) T {
+	t.Helper()
+
+	owner, setupT := t, t
+	if f.scope == ScopeGroup {
+		owner = f.group.t
+		setupT = groupSetupTB{TB: t, group: f.group.t}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if value, ok := f.values[owner]; ok {
+		return value
+	}
+
+	value, teardown := f.setup(setupT)
+	f.values[owner] = value
+
+	// Cleanup runs in last added, first called order, tearing down fixtures in reverse order of creation
+	owner.Cleanup(func() {
+		if teardown != nil {
+			teardown()
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.values, owner)
+	})
+
+	return value
+}
+
+// groupSetupTB - Test passed to the setup of a group scoped fixture.
+// Failures are reported to the test that called Get, on its own goroutine, while cleanups and temp directories last as long as the group.
+type groupSetupTB struct {
+	testing.TB
+	group *testing.T
+}
+
+// Cleanup - Register fn to run when the group completes
+func (g groupSetupTB) Cleanup(fn func()) {
+	g.group.Cleanup(fn)
+}
+
+// TempDir - Create a temp directory removed when the group completes
+func (g groupSetupTB) TempDir() string {
+	return g.group.TempDir()
+}
@@ -0,0 +1,126 @@
+package odize
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFixtureTestScope(t *testing.T) {
+	var events []string
+	created := 0
+
+	t.Run("group", func(t *testing.T) {
+		group := NewGroup(t, nil)
+
+		counter := Fixture(group, func(t testing.TB) (*int, func()) {
+			created++
+			value := 0
+			events = append(events, "setup counter")
+			return &value, func() {
+				events = append(events, "teardown counter")
+			}
+		})
+
+		name := Fixture(group, func(t testing.TB) (string, func()) {
+			count := counter.Get(t)
+			*count++
+			events = append(events, "setup name")
+			return "odize", func() {
+				events = append(events, "teardown name")
+			}
+		})
+
+		err := group.
+			Test("should create fixtures with dependencies", func(t *testing.T) {
+				AssertEqual(t, "odize", name.Get(t))
+				AssertEqual(t, 1, *counter.Get(t))
+			}).
+			Test("should create a fresh fixture per test", func(t *testing.T) {
+				AssertEqual(t, 0, *counter.Get(t))
+			}).
+			Test("should not create unused fixtures", func(t *testing.T) {
+				AssertTrue(t, true)
+			}).
+			Run()
+
+		AssertNoError(t, err)
+	})
+
+	AssertEqual(t, 2, created)
+	AssertEqual(t, []string{
+		"setup counter",
+		"setup name",
+		"teardown name",
+		"teardown counter",
+		"setup counter",
+		"teardown counter",
+	}, events)
+}
+
+func TestFixtureGroupScope(t *testing.T) {
+	created := 0
+	tornDown := 0
+
+	t.Run("group", func(t *testing.T) {
+		group := NewGroup(t, nil)
+
+		shared := Fixture(group, func(t testing.TB) (*[]string, func()) {
+			created++
+			return &[]string{}, func() {
+				tornDown++
+			}
+		}, GroupScoped())
+
+		err := group.
+			Test("should create group fixture", func(t *testing.T) {
+				list := shared.Get(t)
+				*list = append(*list, "first")
+			}).
+			Test("should reuse group fixture", func(t *testing.T) {
+				AssertEqual(t, []string{"first"}, *shared.Get(t))
+				AssertEqual(t, 0, tornDown)
+			}).
+			Run()
+
+		AssertNoError(t, err)
+	})
+
+	AssertEqual(t, 1, created)
+	AssertEqual(t, 1, tornDown)
+}
+
+func TestFixtureGroupScopeSetupFails(t *testing.T) {
+	output := runTestProcess(t, "TestProcessFixtureSetupFails")
+
+	AssertContainsString(t, output, "--- FAIL: TestProcessFixtureSetupFails/uses_fixture")
+	AssertContainsString(t, output, "unable to connect")
+	AssertContainsString(t, output, "--- PASS: TestProcessFixtureSetupFails/runs_next")
+	AssertContainsString(t, output, "temp dir exists: true")
+}
+
+func TestProcessFixtureSetupFails(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	group := NewGroup(t, nil)
+
+	dir := Fixture(group, func(t testing.TB) (string, func()) {
+		return t.TempDir(), nil
+	}, GroupScoped())
+
+	conn := Fixture(group, func(t testing.TB) (string, func()) {
+		t.Fatal("unable to connect")
+		return "", nil
+	}, GroupScoped())
+
+	err := group.
+		Test("uses fixture", func(t *testing.T) {
+			dir.Get(t)
+			conn.Get(t)
+		}).
+		Test("runs next", func(t *testing.T) {
+			_, err := os.Stat(dir.Get(t))
+			t.Logf("temp dir exists: %t", err == nil)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
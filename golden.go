@@ -0,0 +1,139 @@
+package odize
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/code-gorilla-au/env"
+)
+
+// Normaliser - Transform content before it is compared to, or written as, a golden file
+type Normaliser = func([]byte) []byte
+
+var (
+	updateGoldenFlag = flag.Bool("odize.update", false, "update golden files used by odize.AssertGolden")
+
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+	uuidPattern      = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	trailingWSRegex  = regexp.MustCompile(`(?m)[ \t]+(\r?)$`)
+)
+
+const (
+	goldenTimestampPlaceholder = "<timestamp>"
+	goldenUUIDPlaceholder      = "<uuid>"
+)
+
+// AssertGolden compares content against the golden file at path, after applying the normalisers in order.
+//
+// Golden files are written, rather than compared, when the test binary is run with the -odize.update flag,
+// or the ODIZE_UPDATE_GOLDEN environment variable is set to true.
+//
+// Example:
+//
+//	AssertGolden(t, "testdata/report.golden", output, NormaliseTimestamps, NormaliseTrailingWhitespace)
+func AssertGolden(t testing.TB, path string, got []byte, normalisers ...Normaliser) {
+	t.Helper()
+
+	actual := applyNormalisers(got, normalisers)
+
+	if shouldUpdateGolden() {
+		if err := writeGolden(path, actual); err != nil {
+			log(t, fmt.Sprintf("unable to update golden file %s: %v", path, err))
+		}
+
+		return
+	}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		log(t, fmt.Sprintf("unable to read golden file %s: %v\nrun with -odize.update or %s=true to create it", path, err, ODIZE_UPDATE_GOLDEN))
+		return
+	}
+
+	expected := applyNormalisers(content, normalisers)
+
+	if !bytes.Equal(expected, actual) {
+		log(t, decorateGoldenDiff(path, expected, actual))
+	}
+}
+
+// NormaliseTimestamps replaces RFC3339 style timestamps with a stable placeholder
+func NormaliseTimestamps(content []byte) []byte {
+	return timestampPattern.ReplaceAll(content, []byte(goldenTimestampPlaceholder))
+}
+
+// NormaliseUUIDs replaces UUIDs with a stable placeholder
+func NormaliseUUIDs(content []byte) []byte {
+	return uuidPattern.ReplaceAll(content, []byte(goldenUUIDPlaceholder))
+}
+
+// NormaliseTrailingWhitespace removes trailing spaces and tabs from each line
+func NormaliseTrailingWhitespace(content []byte) []byte {
+	return trailingWSRegex.ReplaceAll(content, []byte("$1"))
+}
+
+// NormaliseJSONKeys re-encodes JSON content with sorted keys and consistent indentation.
+// Numbers are kept as written, so large integers and trailing zeros are not lost to float64 precision.
+// Content that is not valid JSON is returned untouched.
+func NormaliseJSONKeys(content []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return content
+	}
+
+	// reject trailing content, matching json.Unmarshal
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return content
+	}
+
+	sorted, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return content
+	}
+
+	return append(sorted, '\n')
+}
+
+// applyNormalisers applies each normaliser in order
+func applyNormalisers(content []byte, normalisers []Normaliser) []byte {
+	result := content
+	for _, normalise := range normalisers {
+		result = normalise(result)
+	}
+
+	return result
+}
+
+// shouldUpdateGolden checks if golden files should be written instead of compared
+func shouldUpdateGolden() bool {
+	return *updateGoldenFlag || env.GetAsBool(ODIZE_UPDATE_GOLDEN)
+}
+
+// writeGolden writes the golden file, creating any missing directories
+func writeGolden(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+func decorateGoldenDiff(path string, expected, actual []byte) string {
+	buf := new(bytes.Buffer)
+
+	buf.WriteString(decorateBlock("Golden ("+path+")", string(expected), "+"))
+	buf.WriteString(decorateBlock("Got", string(actual), "-"))
+
+	return buf.String()
+}
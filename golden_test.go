@@ -0,0 +1,81 @@
+package odize
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAssertGolden(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should match golden file after normalising", func(t *testing.T) {
+			got := []byte("report generated at 2024-01-02T03:04:05Z   \nid: 3F2504E0-4F89-11D3-9A0C-0305E82C3301\nstatus: ok\t\n")
+			AssertGolden(t, "testdata/report.golden", got, NormaliseTimestamps, NormaliseUUIDs, NormaliseTrailingWhitespace)
+		}).
+		Test("should match golden file with sorted json keys", func(t *testing.T) {
+			got := []byte(`{"b":{"d":"x","c":true},"a":1}`)
+			AssertGolden(t, "testdata/sorted.golden", got, NormaliseJSONKeys)
+		}).
+		Test("should write golden file when update is set", func(t *testing.T) {
+			t.Setenv(ODIZE_UPDATE_GOLDEN, "true")
+			path := filepath.Join(t.TempDir(), "nested", "new.golden")
+
+			AssertGolden(t, path, []byte("hello  \n"), NormaliseTrailingWhitespace)
+
+			content, err := os.ReadFile(path)
+			AssertNoError(t, err)
+			AssertEqual(t, "hello\n", string(content))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestNormalisers(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should replace timestamps with offsets", func(t *testing.T) {
+			result := NormaliseTimestamps([]byte("at 2024-01-02 03:04:05.123+10:00 done"))
+			AssertEqual(t, "at <timestamp> done", string(result))
+		}).
+		Test("should replace lower case uuids", func(t *testing.T) {
+			result := NormaliseUUIDs([]byte("id=3f2504e0-4f89-11d3-9a0c-0305e82c3301"))
+			AssertEqual(t, "id=<uuid>", string(result))
+		}).
+		Test("should keep carriage returns when trimming whitespace", func(t *testing.T) {
+			result := NormaliseTrailingWhitespace([]byte("a \t\r\nb  "))
+			AssertEqual(t, "a\r\nb", string(result))
+		}).
+		Test("should leave invalid json untouched", func(t *testing.T) {
+			result := NormaliseJSONKeys([]byte("not json"))
+			AssertEqual(t, "not json", string(result))
+		}).
+		Test("should leave json with trailing content untouched", func(t *testing.T) {
+			result := NormaliseJSONKeys([]byte(`{"a":1} {"b":2}`))
+			AssertEqual(t, `{"a":1} {"b":2}`, string(result))
+		}).
+		Test("should keep json numbers as written", func(t *testing.T) {
+			result := NormaliseJSONKeys([]byte(`{"id":12345678901234567890,"price":1.10}`))
+			AssertEqual(t, "{\n  \"id\": 12345678901234567890,\n  \"price\": 1.10\n}\n", string(result))
+		}).
+		Test("should not hide differences between large integers", func(t *testing.T) {
+			a := NormaliseJSONKeys([]byte(`{"id":12345678901234567890}`))
+			b := NormaliseJSONKeys([]byte(`{"id":12345678901234567891}`))
+			AssertFalse(t, string(a) == string(b))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestDecorateGoldenDiff(t *testing.T) {
+	result := decorateGoldenDiff("testdata/a.golden", []byte("want"), []byte("got"))
+
+	AssertTrue(t, strings.Contains(result, "Golden (testdata/a.golden)"))
+	AssertTrue(t, strings.Contains(result, "+\twant"))
+	AssertTrue(t, strings.Contains(result, "-\tgot"))
+}
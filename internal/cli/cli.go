@@ -0,0 +1,104 @@
+// Package cli implements the odize command.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `odize - list and run odize tests, and generate mocks
+
+Usage:
+
+	odize list [flags] [packages]
+	odize run [flags] [packages] [-- go test flags]
+	odize watch [flags] [packages]
+	odize mockgen [flags] <interface>
+
+Commands:
+
+	list	list test groups, tags and tests found in packages
+	run	run go test with odize filters, and print a summary by group and tag
+	watch	rerun the tests of packages when their files change
+	mockgen	generate a mock of an interface, built on odize.Mock
+
+Run "odize <command> -h" for the flags of a command.
+`
+
+var (
+	ErrUnknownCommand = errors.New("unknown command")
+)
+
+// IO - Streams and working directory of a command
+type IO struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	Dir    string
+}
+
+// Run - Run the odize command with the arguments, excluding the program name. Returns the exit code.
+func Run(args []string, stdio IO) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stdio.Stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "list":
+		err = runList(args[1:], stdio)
+	case "run":
+		err = runTests(args[1:], stdio)
+	case "watch":
+		err = runWatch(args[1:], stdio)
+	case "mockgen":
+		err = runMockgen(args[1:], stdio)
+	case "help", "-h", "--help":
+		_, _ = fmt.Fprint(stdio.Stdout, usage)
+		return 0
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnknownCommand, args[0])
+	}
+
+	return exitCode(err, stdio.Stderr)
+}
+
+// exitCode reports the error and returns the exit code for the command
+func exitCode(err error, stderr io.Writer) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	_, _ = fmt.Fprintln(stderr, "odize:", err)
+	return 1
+}
+
+// ExitError - Exit with the code without reporting an error, e.g. when go test fails
+type ExitError struct {
+	Code int
+}
+
+// Error - return error string
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit code %d", e.Code)
+}
+
+// DefaultIO - Standard streams and the current working directory
+func DefaultIO() IO {
+	dir, _ := os.Getwd()
+
+	return IO{
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		Dir:    dir,
+	}
+}
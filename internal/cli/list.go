@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+const untagged = "(untagged)"
+
+// runList lists the test groups, tags and tests found in packages
+func runList(args []string, stdio IO) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(stdio.Stderr)
+	tags := fs.String("tags", "", "only list groups with one of the comma separated tags")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	groups, err := discoverGroups(stdio.Dir, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	groups = filterGroups(groups, splitTags(*tags))
+	printGroups(stdio.Stdout, groups)
+
+	return nil
+}
+
+// discoverGroups resolves package patterns and scans them for test groups
+func discoverGroups(dir string, patterns []string) ([]Group, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	packages, err := listPackages(dir, patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPackages(packages)
+}
+
+// filterGroups keeps groups that run with the tags, matching the ODIZE_TAGS behaviour of odize
+func filterGroups(groups []Group, tags []string) []Group {
+	if len(tags) == 0 {
+		return groups
+	}
+
+	var filtered []Group
+	for _, g := range groups {
+		if slices.ContainsFunc(g.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			filtered = append(filtered, g)
+		}
+	}
+
+	return filtered
+}
+
+// printGroups prints groups by package, followed by the number of groups and tests for each tag
+func printGroups(w io.Writer, groups []Group) {
+	currentPkg := ""
+	for _, g := range groups {
+		if g.Package != currentPkg {
+			if currentPkg != "" {
+				_, _ = fmt.Fprintln(w)
+			}
+
+			currentPkg = g.Package
+			_, _ = fmt.Fprintln(w, g.Package)
+		}
+
+		_, _ = fmt.Fprintf(w, "  %s%s\n", g.Name, formatTags(g.Tags))
+		for _, test := range g.Tests {
+			_, _ = fmt.Fprintf(w, "    - %s\n", test)
+		}
+	}
+
+	if len(groups) == 0 {
+		_, _ = fmt.Fprintln(w, "no test groups found")
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "\ntags")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, tag := range sortedTags(groups) {
+		groupCount, testCount := 0, 0
+		for _, g := range groups {
+			if hasTag(g, tag) {
+				groupCount++
+				testCount += len(g.Tests)
+			}
+		}
+
+		_, _ = fmt.Fprintf(tw, "  %s\t%d groups\t%d tests\n", tag, groupCount, testCount)
+	}
+	_ = tw.Flush()
+}
+
+// sortedTags returns the unique tags across groups, with untagged groups last
+func sortedTags(groups []Group) []string {
+	var tags []string
+	hasUntagged := false
+	for _, g := range groups {
+		if len(g.Tags) == 0 {
+			hasUntagged = true
+		}
+
+		for _, tag := range g.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	slices.Sort(tags)
+	if hasUntagged {
+		tags = append(tags, untagged)
+	}
+
+	return tags
+}
+
+// hasTag checks if a group has the tag, where untagged matches groups without tags
+func hasTag(g Group, tag string) bool {
+	if tag == untagged {
+		return len(g.Tags) == 0
+	}
+
+	return slices.Contains(g.Tags, tag)
+}
+
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	return " [" + strings.Join(tags, ", ") + "]"
+}
+
+// splitTags splits comma separated tags
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestFilterGroups(t *testing.T) {
+	groups := []Group{
+		{Name: "TestUnit", Tags: []string{"unit"}},
+		{Name: "TestDB", Tags: []string{"integration", "db"}},
+		{Name: "TestUntagged"},
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should return all groups without tags", func(t *testing.T) {
+			odize.AssertEqual(t, 3, len(filterGroups(groups, nil)))
+		}).
+		Test("should return groups matching any tag", func(t *testing.T) {
+			result := filterGroups(groups, []string{"db", "unit"})
+			odize.AssertEqual(t, 2, len(result))
+			odize.AssertEqual(t, "TestUnit", result[0].Name)
+			odize.AssertEqual(t, "TestDB", result[1].Name)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestPrintGroups(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printGroups(buf, []Group{
+		{Package: "example.com/a", Name: "TestUnit", Tags: []string{"unit"}, Tests: []string{"should pass"}},
+		{Package: "example.com/a", Name: "TestUntagged", Tests: []string{"should pass", "should fail"}},
+	})
+
+	output := buf.String()
+
+	odize.AssertTrue(t, strings.Contains(output, "example.com/a\n  TestUnit [unit]\n    - should pass\n"))
+	odize.AssertTrue(t, strings.Contains(output, "unit        1 groups  1 tests"))
+	odize.AssertTrue(t, strings.Contains(output, "(untagged)  1 groups  2 tests"))
+}
+
+func TestSplitTags(t *testing.T) {
+	odize.AssertEqual(t, []string{"unit", "db"}, splitTags(" unit, ,db"))
+	odize.AssertNil(t, splitTags(""))
+}
@@ -0,0 +1,379 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const odizeImportPath = "github.com/code-gorilla-au/odize"
+
+var (
+	ErrMissingInterface = errors.New("expected the name of an interface")
+	ErrNotInterface     = errors.New("not an interface")
+	ErrTypeNotFound     = errors.New("type not found")
+	ErrNoPackage        = errors.New("no package found")
+	ErrMethodClash      = errors.New("interface method clashes with the mock API")
+)
+
+// mockAPI - Methods of odize.Mock forwarded by the generated mock, and the field holding the mock
+var mockAPI = []string{"On", "Verify", "Method", "CallHistory", "Reset", "mock"}
+
+// mockOptions - Flags of the mockgen command, and the interface being mocked
+type mockOptions struct {
+	dir       string
+	pkg       string
+	name      string
+	iface     string
+	srcPkg    *types.Package
+	srcIface  *types.Interface
+	samePkg   bool
+	importFor map[string]string
+}
+
+// runMockgen generates a mock of an interface, built on odize.Mock
+func runMockgen(args []string, stdio IO) error {
+	fs := flag.NewFlagSet("mockgen", flag.ContinueOnError)
+	fs.SetOutput(stdio.Stderr)
+	dir := fs.String("dir", ".", "directory of the package declaring the interface")
+	out := fs.String("out", "", "file to write the mock to, defaults to stdout")
+	pkg := fs.String("package", "", "package of the generated file, defaults to the package declaring the interface")
+	name := fs.String("name", "", "name of the mock, defaults to Mock<Interface>")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return ErrMissingInterface
+	}
+
+	opts := mockOptions{
+		dir:   resolvePath(stdio.Dir, *dir),
+		pkg:   *pkg,
+		name:  *name,
+		iface: fs.Arg(0),
+	}
+
+	source, err := generateMock(&opts)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = stdio.Stdout.Write(source)
+		return err
+	}
+
+	return os.WriteFile(resolvePath(stdio.Dir, *out), source, 0o600)
+}
+
+// generateMock loads the interface and returns the formatted source of its mock
+func generateMock(opts *mockOptions) ([]byte, error) {
+	pkg, iface, err := loadInterface(opts.dir, opts.iface)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.srcPkg = pkg
+	opts.srcIface = iface
+
+	if opts.pkg == "" {
+		opts.pkg = pkg.Name()
+	}
+
+	if opts.name == "" {
+		opts.name = "Mock" + opts.iface
+	}
+
+	for i := range iface.NumMethods() {
+		if name := iface.Method(i).Name(); slices.Contains(mockAPI, name) {
+			return nil, fmt.Errorf("%w: %s.%s would hide %s of the mock", ErrMethodClash, opts.iface, name, name)
+		}
+	}
+
+	opts.samePkg = opts.pkg == pkg.Name()
+	opts.importFor = map[string]string{}
+
+	body := new(bytes.Buffer)
+	writeMock(body, opts)
+
+	src := new(bytes.Buffer)
+	_, _ = fmt.Fprintf(src, "// Code generated by odize mockgen. DO NOT EDIT.\n\npackage %s\n\n", opts.pkg)
+	writeImports(src, opts.importFor)
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format mock: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// loadInterface type checks the non test files of the package in dir, and looks up the interface.
+// Imports are type checked from source, so no network access or build cache is needed.
+func loadInterface(dir string, name string) (*types.Package, *types.Interface, error) {
+	packages, err := listPackages(dir, []string{"."})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(packages) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNoPackage, dir)
+	}
+
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(buildPkg.GoFiles))
+	for _, file := range buildPkg.GoFiles {
+		parsed, err := parser.ParseFile(fset, filepath.Join(dir, file), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files = append(files, parsed)
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Report the interface even if unrelated code does not type check
+		Error: func(error) {},
+	}
+
+	pkg, err := conf.Check(packages[0].ImportPath, fset, files, nil)
+	if pkg == nil {
+		return nil, nil, err
+	}
+
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil, nil, fmt.Errorf("%w: %s in %s", ErrTypeNotFound, name, pkg.Path())
+	}
+
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNotInterface, name)
+	}
+
+	if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+		return nil, nil, fmt.Errorf("%w: %s is generic, generic interfaces are not supported", ErrNotInterface, name)
+	}
+
+	return pkg, iface, nil
+}
+
+// writeMock writes the mock struct, constructor and methods
+func writeMock(w *bytes.Buffer, opts *mockOptions) {
+	qualifier := opts.qualifier()
+	odize := opts.importName(odizeImportPath, "odize")
+	ifaceName := opts.iface
+	if !opts.samePkg {
+		ifaceName = qualifier(opts.srcPkg) + "." + ifaceName
+	}
+
+	_, _ = fmt.Fprintf(w, "// %s - Mock of %s, generated by odize mockgen\n", opts.name, opts.iface)
+	_, _ = fmt.Fprintf(w, "type %s struct {\n\tmock *%s.Mock\n}\n\n", opts.name, odize)
+	_, _ = fmt.Fprintf(w, "var _ %s = (*%s)(nil)\n\n", ifaceName, opts.name)
+	_, _ = fmt.Fprintf(w, "// New%s - Create a %s\n", opts.name, opts.name)
+	_, _ = fmt.Fprintf(w, "func New%s() *%s {\n\treturn &%s{mock: %s.NewMock()}\n}\n", opts.name, opts.name, opts.name, odize)
+
+	writeMockAPI(w, opts, odize)
+
+	for i := range opts.srcIface.NumMethods() {
+		method := opts.srcIface.Method(i)
+		sig, ok := method.Type().(*types.Signature)
+		if !ok {
+			continue
+		}
+
+		writeMethod(w, opts, method.Name(), sig, odize, qualifier)
+	}
+}
+
+// writeMockAPI writes the methods forwarded to odize.Mock. The mock is a named field rather than embedded,
+// so interface methods such as Mock do not clash with the field.
+func writeMockAPI(w *bytes.Buffer, opts *mockOptions, odize string) {
+	testingPkg := opts.importName("testing", "testing")
+
+	_, _ = fmt.Fprintf(w, "\n// On - Expect a call to the method with the arguments, see odize.Mock.On\n")
+	_, _ = fmt.Fprintf(w, "func (m *%s) On(method string, args ...any) *%s.Expectation {\n\treturn m.mock.On(method, args...)\n}\n", opts.name, odize)
+	_, _ = fmt.Fprintf(w, "\n// Verify - Fail the test if an expectation was not met, see odize.Mock.Verify\n")
+	_, _ = fmt.Fprintf(w, "func (m *%s) Verify(t %s.TB) {\n\tt.Helper()\n\n\tm.mock.Verify(t)\n}\n", opts.name, testingPkg)
+	_, _ = fmt.Fprintf(w, "\n// Method - Calls to a single method, used with the spy assertions\n")
+	_, _ = fmt.Fprintf(w, "func (m *%s) Method(method string) *%s.MockMethod {\n\treturn m.mock.Method(method)\n}\n", opts.name, odize)
+	_, _ = fmt.Fprintf(w, "\n// CallHistory - Calls made to every method of the mock, in the order they were made\n")
+	_, _ = fmt.Fprintf(w, "func (m *%s) CallHistory() []%s.SpyCall {\n\treturn m.mock.CallHistory()\n}\n", opts.name, odize)
+	_, _ = fmt.Fprintf(w, "\n// Reset - Clear the recorded calls, keeping the expectations\n")
+	_, _ = fmt.Fprintf(w, "func (m *%s) Reset() {\n\tm.mock.Reset()\n}\n", opts.name)
+}
+
+// writeMethod writes a method that records the call on the mock and returns the expected values
+func writeMethod(w *bytes.Buffer, opts *mockOptions, name string, sig *types.Signature, odize string, qualifier types.Qualifier) {
+	params := sig.Params()
+	names := make([]string, params.Len())
+	decls := make([]string, params.Len())
+	for i := range params.Len() {
+		param := params.At(i)
+		names[i] = paramName(param.Name(), i)
+
+		typeName := types.TypeString(param.Type(), qualifier)
+		if sig.Variadic() && i == params.Len()-1 {
+			slice, ok := param.Type().(*types.Slice)
+			if ok {
+				typeName = "..." + types.TypeString(slice.Elem(), qualifier)
+			}
+		}
+
+		decls[i] = names[i] + " " + typeName
+	}
+
+	results := sig.Results()
+	resultTypes := make([]string, results.Len())
+	for i := range results.Len() {
+		resultTypes[i] = types.TypeString(results.At(i).Type(), qualifier)
+	}
+
+	called := fmt.Sprintf("m.mock.Called(%s)", strings.Join(append([]string{fmt.Sprintf("%q", name)}, names...), ", "))
+
+	_, _ = fmt.Fprintf(w, "\n// %s - Mock of %s.%s\n", name, opts.iface, name)
+	_, _ = fmt.Fprintf(w, "func (m *%s) %s(%s) %s {\n", opts.name, name, strings.Join(decls, ", "), formatResults(resultTypes))
+
+	if len(resultTypes) == 0 {
+		_, _ = fmt.Fprintf(w, "\t%s\n}\n", called)
+		return
+	}
+
+	returns := make([]string, len(resultTypes))
+	for i, typeName := range resultTypes {
+		returns[i] = fmt.Sprintf("%s.MockResult[%s](results, %d)", odize, typeName, i)
+	}
+
+	_, _ = fmt.Fprintf(w, "\tresults := %s\n\n\treturn %s\n}\n", called, strings.Join(returns, ", "))
+}
+
+// qualifier names packages by their import name, recording each import
+func (opts *mockOptions) qualifier() types.Qualifier {
+	return func(pkg *types.Package) string {
+		if opts.samePkg && pkg.Path() == opts.srcPkg.Path() {
+			return ""
+		}
+
+		return opts.importName(pkg.Path(), pkg.Name())
+	}
+}
+
+// importName records an import, returning a unique name for the package
+func (opts *mockOptions) importName(path string, name string) string {
+	if existing, ok := opts.importFor[path]; ok {
+		return existing
+	}
+
+	unique := name
+	for i := 2; slices.Contains(mapValues(opts.importFor), unique); i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+
+	opts.importFor[path] = unique
+
+	return unique
+}
+
+// writeImports writes the import block, standard library packages first, aliasing packages whose name does not match the last element of their path
+func writeImports(w *bytes.Buffer, imports map[string]string) {
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+
+	slices.SortFunc(paths, func(a, b string) int {
+		if isStdLib(a) != isStdLib(b) {
+			if isStdLib(a) {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	_, _ = fmt.Fprintln(w, "import (")
+	for i, path := range paths {
+		if i > 0 && isStdLib(paths[i-1]) && !isStdLib(path) {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		if name := imports[path]; name != filepath.Base(path) {
+			_, _ = fmt.Fprintf(w, "\t%s %q\n", name, path)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "\t%q\n", path)
+	}
+
+	_, _ = fmt.Fprintln(w, ")")
+	_, _ = fmt.Fprintln(w)
+}
+
+// isStdLib checks if the import path is a standard library package, which have no dot in the first path element
+func isStdLib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// paramName returns the parameter name, or a generated name if it is unnamed or clashes with the generated code
+func paramName(name string, i int) string {
+	switch name {
+	case "", "_", "m", "results":
+		return fmt.Sprintf("arg%d", i)
+	default:
+		return name
+	}
+}
+
+// formatResults formats the result list of a method signature
+func formatResults(resultTypes []string) string {
+	switch len(resultTypes) {
+	case 0:
+		return ""
+	case 1:
+		return resultTypes[0]
+	default:
+		return "(" + strings.Join(resultTypes, ", ") + ")"
+	}
+}
+
+// resolvePath resolves path relative to dir
+func resolvePath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
+
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+
+	return values
+}
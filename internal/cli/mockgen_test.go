@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestGenerateMock(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should generate mock in the interface's package", func(t *testing.T) {
+			source, err := generateMock(&mockOptions{dir: "testdata/mockgen", iface: "Store"})
+			odize.AssertNoError(t, err)
+
+			odize.AssertGolden(t, "testdata/mockgen/store_mock.golden", source)
+		}).
+		Test("should import the interface's package from another package", func(t *testing.T) {
+			source, err := generateMock(&mockOptions{dir: "testdata/mockgen", iface: "Store", pkg: "store_test", name: "FakeStore"})
+			odize.AssertNoError(t, err)
+
+			odize.AssertGolden(t, "testdata/mockgen/store_test_mock.golden", source)
+		}).
+		Test("should generate methods named after the mock", func(t *testing.T) {
+			source, err := generateMock(&mockOptions{dir: "testdata/mockgen", iface: "Recorder"})
+			odize.AssertNoError(t, err)
+
+			odize.AssertGolden(t, "testdata/mockgen/recorder_mock.golden", source)
+		}).
+		Test("should return error if a method would hide the mock API", func(t *testing.T) {
+			_, err := generateMock(&mockOptions{dir: "testdata/mockgen", iface: "Emitter"})
+			odize.AssertTrue(t, errors.Is(err, ErrMethodClash))
+			odize.AssertContainsString(t, err.Error(), "Emitter.On would hide On of the mock")
+		}).
+		Test("should return error if type is not an interface", func(t *testing.T) {
+			_, err := generateMock(&mockOptions{dir: "testdata/mockgen", iface: "User"})
+			odize.AssertTrue(t, errors.Is(err, ErrNotInterface))
+		}).
+		Test("should return error if type is not found", func(t *testing.T) {
+			_, err := generateMock(&mockOptions{dir: "testdata/mockgen", iface: "Missing"})
+			odize.AssertTrue(t, errors.Is(err, ErrTypeNotFound))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestRunMockgen(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should write mock to file", func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "store_mock.go")
+			stdio := IO{Stdout: new(bytes.Buffer), Stderr: new(bytes.Buffer), Dir: "."}
+
+			code := Run([]string{"mockgen", "-dir", "testdata/mockgen", "-out", out, "Store"}, stdio)
+			odize.AssertEqual(t, 0, code)
+
+			content, err := os.ReadFile(out)
+			odize.AssertNoError(t, err)
+			odize.AssertGolden(t, "testdata/mockgen/store_mock.golden", content)
+		}).
+		Test("should fail without an interface", func(t *testing.T) {
+			stderr := new(bytes.Buffer)
+			stdio := IO{Stdout: new(bytes.Buffer), Stderr: stderr, Dir: "."}
+
+			code := Run([]string{"mockgen", "-dir", "testdata/mockgen"}, stdio)
+			odize.AssertEqual(t, 1, code)
+			odize.AssertEqual(t, "odize: expected the name of an interface\n", stderr.String())
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
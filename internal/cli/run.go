@@ -0,0 +1,343 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+// testEvent - Event emitted by go test -json
+type testEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Output  string
+}
+
+// testResult - Outcome of a single test
+type testResult struct {
+	Package string
+	Test    string
+	Action  string
+	Output  []string
+}
+
+// groupSummary - Outcome of the tests within a group
+type groupSummary struct {
+	Package string
+	Name    string
+	Tags    []string
+	Pass    int
+	Fail    int
+	Skip    int
+}
+
+// runOptions - Flags for the run command
+type runOptions struct {
+	tags    string
+	shard   string
+	filter  string
+	goTest  []string
+	pattern []string
+}
+
+// runTests runs go test with odize filters, printing failures and a summary by group and tag
+func runTests(args []string, stdio IO) error {
+	opts, err := parseRunArgs(args, stdio.Stderr)
+	if err != nil {
+		return err
+	}
+
+	groups, err := discoverGroups(stdio.Dir, opts.pattern)
+	if err != nil {
+		return err
+	}
+
+	results, runErr := goTest(stdio, opts)
+
+	printFailures(stdio.Stdout, results)
+	printSummary(stdio.Stdout, summarise(groups, results))
+
+	return runErr
+}
+
+// parseRunArgs parses flags, packages, and go test flags provided after "--"
+func parseRunArgs(args []string, stderr io.Writer) (runOptions, error) {
+	opts := runOptions{}
+
+	if index := slices.Index(args, "--"); index >= 0 {
+		opts.goTest = args[index+1:]
+		args = args[:index]
+	}
+
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.tags, "tags", "", "only run groups with one of the comma separated tags, sets "+odize.ODIZE_TAGS)
+	fs.StringVar(&opts.shard, "shard", "", "run a shard of the tests e.g. 1/4, sets "+odize.ODIZE_SHARD)
+	fs.StringVar(&opts.filter, "filter", "", "only run tests matching the glob or /regex/, sets "+odize.ODIZE_FILTER)
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+
+	opts.pattern = fs.Args()
+	if len(opts.pattern) == 0 {
+		opts.pattern = []string{"./..."}
+	}
+
+	return opts, nil
+}
+
+// env returns the odize environment variables for the flags that are set
+func (opts runOptions) env() []string {
+	var result []string
+	for key, value := range map[string]string{
+		odize.ODIZE_TAGS:   opts.tags,
+		odize.ODIZE_SHARD:  opts.shard,
+		odize.ODIZE_FILTER: opts.filter,
+	} {
+		if value != "" {
+			result = append(result, key+"="+value)
+		}
+	}
+
+	slices.Sort(result)
+	return result
+}
+
+// goTest runs go test -json, printing package results as they complete
+func goTest(stdio IO, opts runOptions) ([]testResult, error) {
+	args := append([]string{"test", "-json"}, opts.goTest...)
+	args = append(args, opts.pattern...)
+
+	cmd := exec.Command("go", args...) //nolint:gosec // arguments are passed through to go test
+	cmd.Dir = stdio.Dir
+	cmd.Env = append(os.Environ(), opts.env()...)
+	cmd.Stderr = stdio.Stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	results := parseEvents(stdout, stdio.Stdout)
+
+	if err = cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return results, &ExitError{Code: exitErr.ExitCode()}
+		}
+
+		return results, err
+	}
+
+	return results, nil
+}
+
+// parseEvents reads go test -json events, returning the result of each test.
+// Package results are written to w as they complete.
+func parseEvents(r io.Reader, w io.Writer) []testResult {
+	var results []testResult
+	index := map[string]int{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	for scanner.Scan() {
+		var event testEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// not an event, e.g. build output
+			_, _ = fmt.Fprintln(w, scanner.Text())
+			continue
+		}
+
+		if event.Test == "" {
+			printPackageEvent(w, event)
+			continue
+		}
+
+		key := event.Package + " " + event.Test
+		i, ok := index[key]
+		if !ok {
+			i = len(results)
+			index[key] = i
+			results = append(results, testResult{Package: event.Package, Test: event.Test})
+		}
+
+		switch event.Action {
+		case "output":
+			results[i].Output = append(results[i].Output, event.Output)
+		case "pass", "fail", "skip":
+			results[i].Action = event.Action
+		}
+	}
+
+	return results
+}
+
+func printPackageEvent(w io.Writer, event testEvent) {
+	switch event.Action {
+	case "pass":
+		_, _ = fmt.Fprintf(w, "ok    %s\n", event.Package)
+	case "fail":
+		_, _ = fmt.Fprintf(w, "FAIL  %s\n", event.Package)
+	case "skip":
+		_, _ = fmt.Fprintf(w, "?     %s [no test files]\n", event.Package)
+	}
+}
+
+// printFailures prints the output of failed tests.
+// Parent tests fail with their subtests, so only the tests that produced output are printed.
+func printFailures(w io.Writer, results []testResult) {
+	for _, result := range failedLeafTests(results) {
+		_, _ = fmt.Fprintf(w, "\n--- FAIL: %s (%s)\n", result.Test, result.Package)
+		for _, line := range result.Output {
+			_, _ = fmt.Fprint(w, line)
+		}
+	}
+}
+
+// failedLeafTests returns failed tests that have no failed subtests
+func failedLeafTests(results []testResult) []testResult {
+	var failed []testResult
+	for _, result := range results {
+		if result.Action != "fail" {
+			continue
+		}
+
+		if slices.ContainsFunc(results, func(child testResult) bool {
+			return child.Package == result.Package && child.Action == "fail" && strings.HasPrefix(child.Test, result.Test+"/")
+		}) {
+			continue
+		}
+
+		failed = append(failed, result)
+	}
+
+	return failed
+}
+
+// summarise counts the results of the tests within each group.
+// Tests within a group are the direct subtests of the top level test function that created the group.
+func summarise(groups []Group, results []testResult) []groupSummary {
+	var summaries []groupSummary
+	index := map[string]int{}
+
+	for _, result := range results {
+		parent, _, found := strings.Cut(result.Test, "/")
+		if !found || strings.Count(result.Test, "/") > 1 {
+			continue
+		}
+
+		key := result.Package + " " + parent
+		i, ok := index[key]
+		if !ok {
+			i = len(summaries)
+			index[key] = i
+			summaries = append(summaries, groupSummary{
+				Package: result.Package,
+				Name:    parent,
+				Tags:    tagsFor(groups, result.Package, parent),
+			})
+		}
+
+		switch result.Action {
+		case "pass":
+			summaries[i].Pass++
+		case "fail":
+			summaries[i].Fail++
+		case "skip":
+			summaries[i].Skip++
+		}
+	}
+
+	return summaries
+}
+
+// tagsFor returns the tags of all groups created within a test function
+func tagsFor(groups []Group, pkg string, name string) []string {
+	var tags []string
+	for _, g := range groups {
+		if g.Package != pkg || g.Name != name {
+			continue
+		}
+
+		for _, tag := range g.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// printSummary prints the test counts by group, then by tag
+func printSummary(w io.Writer, summaries []groupSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "\nsummary by group")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, s := range summaries {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s%s\t%d passed\t%d failed\t%d skipped\n", status(s.Fail), s.Name, formatTags(s.Tags), s.Pass, s.Fail, s.Skip)
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintln(w, "\nsummary by tag")
+
+	byTag := map[string]*groupSummary{}
+	var tags []string
+	for _, s := range summaries {
+		tagged := s.Tags
+		if len(tagged) == 0 {
+			tagged = []string{untagged}
+		}
+
+		for _, tag := range tagged {
+			total, ok := byTag[tag]
+			if !ok {
+				total = &groupSummary{}
+				byTag[tag] = total
+				tags = append(tags, tag)
+			}
+
+			total.Pass += s.Pass
+			total.Fail += s.Fail
+			total.Skip += s.Skip
+		}
+	}
+
+	slices.Sort(tags)
+
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, tag := range tags {
+		total := byTag[tag]
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%d passed\t%d failed\t%d skipped\n", status(total.Fail), tag, total.Pass, total.Fail, total.Skip)
+	}
+	_ = tw.Flush()
+}
+
+func status(failed int) string {
+	if failed > 0 {
+		return "FAIL"
+	}
+
+	return "PASS"
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+const sampleEvents = `{"Action":"run","Package":"example.com/a","Test":"TestUser"}
+{"Action":"run","Package":"example.com/a","Test":"TestUser/should_add"}
+{"Action":"pass","Package":"example.com/a","Test":"TestUser/should_add"}
+{"Action":"run","Package":"example.com/a","Test":"TestUser/should_remove"}
+{"Action":"output","Package":"example.com/a","Test":"TestUser/should_remove","Output":"    user_test.go:10: expected 1\n"}
+{"Action":"fail","Package":"example.com/a","Test":"TestUser/should_remove"}
+{"Action":"skip","Package":"example.com/a","Test":"TestUser/should_update"}
+{"Action":"fail","Package":"example.com/a","Test":"TestUser"}
+{"Action":"pass","Package":"example.com/a","Test":"TestOther/should_pass"}
+{"Action":"fail","Package":"example.com/a"}
+`
+
+func TestParseEvents(t *testing.T) {
+	buf := new(bytes.Buffer)
+	results := parseEvents(strings.NewReader(sampleEvents), buf)
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should record each test", func(t *testing.T) {
+			odize.AssertEqual(t, 5, len(results))
+			odize.AssertEqual(t, "fail", results[2].Action)
+			odize.AssertEqual(t, []string{"    user_test.go:10: expected 1\n"}, results[2].Output)
+		}).
+		Test("should print package result", func(t *testing.T) {
+			odize.AssertEqual(t, "FAIL  example.com/a\n", buf.String())
+		}).
+		Test("should only print output of failed leaf tests", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			printFailures(out, results)
+
+			odize.AssertEqual(t, "\n--- FAIL: TestUser/should_remove (example.com/a)\n    user_test.go:10: expected 1\n", out.String())
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestSummarise(t *testing.T) {
+	results := parseEvents(strings.NewReader(sampleEvents), new(bytes.Buffer))
+	groups := []Group{
+		{Package: "example.com/a", Name: "TestUser", Tags: []string{"unit"}},
+	}
+
+	summaries := summarise(groups, results)
+
+	odize.AssertEqual(t, []groupSummary{
+		{Package: "example.com/a", Name: "TestUser", Tags: []string{"unit"}, Pass: 1, Fail: 1, Skip: 1},
+		{Package: "example.com/a", Name: "TestOther", Pass: 1},
+	}, summaries)
+
+	buf := new(bytes.Buffer)
+	printSummary(buf, summaries)
+
+	odize.AssertTrue(t, strings.Contains(buf.String(), "FAIL  TestUser [unit]  1 passed  1 failed  1 skipped"))
+	odize.AssertTrue(t, strings.Contains(buf.String(), "PASS  (untagged)  1 passed  0 failed  0 skipped"))
+}
+
+func TestParseRunArgs(t *testing.T) {
+	opts, err := parseRunArgs([]string{"--tags", "unit", "--shard", "1/4", "./pkg/...", "--", "-count=1", "-v"}, new(bytes.Buffer))
+	odize.AssertNoError(t, err)
+
+	odize.AssertEqual(t, []string{"./pkg/..."}, opts.pattern)
+	odize.AssertEqual(t, []string{"-count=1", "-v"}, opts.goTest)
+	odize.AssertEqual(t, []string{"ODIZE_SHARD=1/4", "ODIZE_TAGS=unit"}, opts.env())
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	stderr := new(bytes.Buffer)
+	code := Run([]string{"unknown"}, IO{Stdout: new(bytes.Buffer), Stderr: stderr})
+
+	odize.AssertEqual(t, 1, code)
+	odize.AssertTrue(t, strings.Contains(stderr.String(), "unknown command: unknown"))
+}
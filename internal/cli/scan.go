@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Group - Test group found by statically scanning test files
+type Group struct {
+	// Package import path
+	Package string
+	// Name of the top level test function the group is created in
+	Name  string
+	Tags  []string
+	Tests []string
+	// Position of the NewGroup call
+	Pos token.Position
+}
+
+// Package - Go package resolved from a package pattern
+type Package struct {
+	ImportPath string
+	Dir        string
+}
+
+// listPackages resolves package patterns e.g. "./..." with go list
+func listPackages(dir string, patterns []string) ([]Package, error) {
+	args := append([]string{"list", "-f", "{{.ImportPath}}\t{{.Dir}}"}, patterns...)
+
+	cmd := exec.Command("go", args...) //nolint:gosec // patterns are passed through to go list
+	cmd.Dir = dir
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var packages []Package
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		importPath, pkgDir, found := strings.Cut(scanner.Text(), "\t")
+		if !found {
+			continue
+		}
+
+		packages = append(packages, Package{ImportPath: importPath, Dir: pkgDir})
+	}
+
+	return packages, scanner.Err()
+}
+
+// scanPackages scans the test files of each package for test groups
+func scanPackages(packages []Package) ([]Group, error) {
+	var groups []Group
+	for _, pkg := range packages {
+		found, err := scanDir(pkg.ImportPath, pkg.Dir)
+		if err != nil {
+			return groups, err
+		}
+
+		groups = append(groups, found...)
+	}
+
+	return groups, nil
+}
+
+// scanDir parses the test files within a directory for NewGroup calls
+func scanDir(importPath string, dir string) ([]Group, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(files)
+
+	var groups []Group
+	fset := token.NewFileSet()
+	for _, file := range files {
+		parsed, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return groups, err
+		}
+
+		for _, decl := range parsed.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+
+			groups = append(groups, scanFunc(fset, importPath, fn)...)
+		}
+	}
+
+	return groups, nil
+}
+
+// scanFunc finds the test groups created within a top level function, along with their tests
+func scanFunc(fset *token.FileSet, importPath string, fn *ast.FuncDecl) []Group {
+	var groups []*Group
+	byVar := map[string]*Group{}
+	byCall := map[*ast.CallExpr]*Group{}
+
+	newGroup := func(call *ast.CallExpr) *Group {
+		g := &Group{
+			Package: importPath,
+			Name:    fn.Name.Name,
+			Tags:    groupTags(call),
+			Pos:     fset.Position(call.Pos()),
+		}
+		groups = append(groups, g)
+		byCall[call] = g
+
+		return g
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+
+		for i, rhs := range assign.Rhs {
+			call, ok := rhs.(*ast.CallExpr)
+			if !ok || !isNewGroup(call) {
+				continue
+			}
+
+			if id, ok := assign.Lhs[i].(*ast.Ident); ok {
+				byVar[id.Name] = newGroup(call)
+			}
+		}
+
+		return true
+	})
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		if isNewGroup(call) && byCall[call] == nil {
+			newGroup(call)
+		}
+
+		return true
+	})
+
+	// method chains are visited outer call first, so record positions to restore the order tests are registered in
+	tests := map[*Group][]*ast.CallExpr{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 || !isMethod(call, "Test", "SyncTest", "Todo") {
+			return true
+		}
+
+		if g := chainRoot(call, byVar, byCall); g != nil {
+			tests[g] = append(tests[g], call)
+		}
+
+		return true
+	})
+
+	slices.SortFunc(groups, func(a, b *Group) int {
+		return a.Pos.Offset - b.Pos.Offset
+	})
+
+	result := make([]Group, 0, len(groups))
+	for _, g := range groups {
+		calls := tests[g]
+		slices.SortFunc(calls, func(a, b *ast.CallExpr) int {
+			return int(a.Args[0].Pos() - b.Args[0].Pos())
+		})
+
+		for _, call := range calls {
+			if name, ok := literalArg(call); ok {
+				g.Tests = append(g.Tests, name)
+			}
+		}
+
+		result = append(result, *g)
+	}
+
+	return result
+}
+
+// chainRoot walks a method chain back to the group it was called on
+func chainRoot(call *ast.CallExpr, byVar map[string]*Group, byCall map[*ast.CallExpr]*Group) *Group {
+	var receiver ast.Expr = call
+	for {
+		current, ok := receiver.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+
+		if g, ok := byCall[current]; ok {
+			return g
+		}
+
+		sel, ok := current.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+
+		receiver = ast.Unparen(sel.X)
+	}
+
+	if id, ok := receiver.(*ast.Ident); ok {
+		return byVar[id.Name]
+	}
+
+	return nil
+}
+
+// isNewGroup checks if the call is odize.NewGroup, or NewGroup within the odize package
+func isNewGroup(call *ast.CallExpr) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel.Name == "NewGroup"
+	case *ast.Ident:
+		return fun.Name == "NewGroup"
+	}
+
+	return false
+}
+
+// isMethod checks if the call is a method call with one of the names
+func isMethod(call *ast.CallExpr, names ...string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && slices.Contains(names, sel.Sel.Name)
+}
+
+// groupTags reads literal tags from NewGroup(t, &[]string{"unit"})
+func groupTags(call *ast.CallExpr) []string {
+	if len(call.Args) < 2 {
+		return nil
+	}
+
+	expr := ast.Unparen(call.Args[1])
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	var tags []string
+	for _, elt := range lit.Elts {
+		if value, ok := stringLit(elt); ok {
+			tags = append(tags, value)
+		}
+	}
+
+	return tags
+}
+
+// literalArg returns the first argument of a call if it is a string literal
+func literalArg(call *ast.CallExpr) (string, bool) {
+	if len(call.Args) == 0 {
+		return "", false
+	}
+
+	return stringLit(call.Args[0])
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := ast.Unparen(expr).(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestScanDir(t *testing.T) {
+	groups, err := scanDir("example.com/sample", "testdata/scan")
+	odize.AssertNoError(t, err)
+
+	group := odize.NewGroup(t, nil)
+
+	err = group.
+		Test("should find each group", func(t *testing.T) {
+			odize.AssertEqual(t, 2, len(groups))
+		}).
+		Test("should read group name and tags", func(t *testing.T) {
+			odize.AssertEqual(t, "TestTagged", groups[0].Name)
+			odize.AssertEqual(t, "example.com/sample", groups[0].Package)
+			odize.AssertEqual(t, []string{"unit", "db"}, groups[0].Tags)
+		}).
+		Test("should read tests in registration order", func(t *testing.T) {
+			odize.AssertEqual(t, []string{"should add user", "should remove user", "should update user", "should expire user"}, groups[0].Tests)
+		}).
+		Test("should read chained group without tags", func(t *testing.T) {
+			odize.AssertEqual(t, "TestChained", groups[1].Name)
+			odize.AssertNil(t, groups[1].Tags)
+			odize.AssertEqual(t, []string{"should pass"}, groups[1].Tests)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+	"io"
+)
+
+type User struct {
+	Name string
+}
+
+type Store interface {
+	io.Closer
+	Get(ctx context.Context, id string) (User, error)
+	Save(ctx context.Context, users ...User) error
+	Ping()
+	Each(func(User) bool)
+}
+
+type Recorder interface {
+	Mock(name string) bool
+	Called() int
+}
+
+type Emitter interface {
+	On(event string, fn func())
+}
+
+type NotInterface struct{}
@@ -0,0 +1,30 @@
+package sample
+
+import (
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestTagged(t *testing.T) {
+	group := odize.NewGroup(t, &[]string{"unit", "db"})
+
+	err := group.
+		Test("should add user", func(t *testing.T) {}).
+		Todo("should remove user").
+		Test("should update user", func(t *testing.T) {}).
+		SyncTest("should expire user", func(t *testing.T) {}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestChained(t *testing.T) {
+	err := odize.NewGroup(t, nil).
+		Test("should pass", func(t *testing.T) {}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func helper() {}
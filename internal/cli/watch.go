@@ -0,0 +1,513 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"go/build"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const watchHelp = `watch commands, press enter after each command:
+
+	enter      rerun the last run
+	a          rerun all packages
+	f          rerun failed tests only
+	t <tags>   only run groups with the comma separated tags, "t" to clear
+	p <filter> focus on tests matching the glob or /regex/, "p" to clear
+	c          clear tags and focus
+	h          show this help
+	q          quit
+`
+
+// watchCommand - Command entered while watching
+type watchCommand struct {
+	name string
+	arg  string
+}
+
+// snapshot - Modification time and size of each watched file
+type snapshot map[string]fileStamp
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// watcher - State of a watch session
+type watcher struct {
+	stdio    IO
+	opts     runOptions
+	packages []Package
+	// directories walked for new packages, the root of each recursive pattern
+	roots []string
+	files snapshot
+	// last packages run, rerun with enter
+	last []string
+	// failed results from the last run
+	failed []testResult
+}
+
+// runWatch watches Go files, rerunning the tests of packages with changed files
+func runWatch(args []string, stdio IO) error {
+	fset := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fset.SetOutput(stdio.Stderr)
+	interval := fset.Duration("interval", 500*time.Millisecond, "how often to poll for file changes")
+	tags := fset.String("tags", "", "only run groups with one of the comma separated tags")
+	filter := fset.String("filter", "", "only run tests matching the glob or /regex/")
+
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+
+	w := &watcher{
+		stdio: stdio,
+		opts:  runOptions{tags: *tags, filter: *filter, pattern: fset.Args()},
+	}
+	if len(w.opts.pattern) == 0 {
+		w.opts.pattern = []string{"./..."}
+	}
+
+	if err := w.refreshPackages(); err != nil {
+		return err
+	}
+
+	commands := readCommands(stdio.Stdin)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	_, _ = fmt.Fprint(stdio.Stdout, watchHelp)
+	w.run(w.importPaths(), "")
+
+	for {
+		select {
+		case cmd, ok := <-commands:
+			if !ok || cmd.name == "q" {
+				return nil
+			}
+
+			w.handle(cmd)
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// readCommands reads a command per line from r
+func readCommands(r io.Reader) <-chan watchCommand {
+	commands := make(chan watchCommand)
+
+	go func() {
+		defer close(commands)
+
+		if r == nil {
+			select {}
+		}
+
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			commands <- parseCommand(scanner.Text())
+		}
+	}()
+
+	return commands
+}
+
+// parseCommand parses a command and its argument e.g. "t unit,db"
+func parseCommand(line string) watchCommand {
+	name, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	return watchCommand{name: strings.ToLower(name), arg: strings.TrimSpace(arg)}
+}
+
+// handle runs a command entered while watching
+func (w *watcher) handle(cmd watchCommand) {
+	switch cmd.name {
+	case "":
+		w.run(w.last, "")
+	case "a":
+		w.run(w.importPaths(), "")
+	case "f":
+		w.rerunFailed()
+	case "t":
+		w.opts.tags = cmd.arg
+		w.run(w.importPaths(), "")
+	case "p":
+		w.opts.filter = cmd.arg
+		w.run(w.importPaths(), "")
+	case "c":
+		w.opts.tags, w.opts.filter = "", ""
+		w.run(w.importPaths(), "")
+	default:
+		_, _ = fmt.Fprint(w.stdio.Stdout, watchHelp)
+	}
+}
+
+// poll checks for changed files, rerunning the packages they belong to.
+// Files added to new directories, or removed with their directory, refresh the watched packages.
+func (w *watcher) poll() {
+	current, err := snapshotDirs(w.roots, w.dirs())
+	if err != nil {
+		_, _ = fmt.Fprintln(w.stdio.Stderr, "odize:", err)
+		return
+	}
+
+	changed := changedFiles(w.files, current)
+	w.files = current
+	if len(changed) == 0 {
+		return
+	}
+
+	if w.packagesChanged(changed) {
+		if err = w.refreshPackages(); err != nil {
+			// the packages may be mid edit, keep watching until they list again
+			_, _ = fmt.Fprintln(w.stdio.Stderr, "odize:", err)
+			return
+		}
+	}
+
+	if affected := packagesFor(changed, w.packages); len(affected) > 0 {
+		_, _ = fmt.Fprintf(w.stdio.Stdout, "\nchanged: %s\n", strings.Join(changed, ", "))
+		w.run(affected, "")
+	}
+}
+
+// packagesChanged checks if the changed files are outside the known packages, or a known package directory was removed
+func (w *watcher) packagesChanged(changed []string) bool {
+	dirs := w.dirs()
+	for _, dir := range uniqueDirs(changed) {
+		if !slices.ContainsFunc(dirs, func(known string) bool { return filepath.Clean(known) == dir }) {
+			return true
+		}
+
+		if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rerunFailed reruns only the tests that failed in the last run
+func (w *watcher) rerunFailed() {
+	if len(w.failed) == 0 {
+		_, _ = fmt.Fprintln(w.stdio.Stdout, "no failed tests to rerun")
+		return
+	}
+
+	var packages []string
+	for _, result := range w.failed {
+		if !slices.Contains(packages, result.Package) {
+			packages = append(packages, result.Package)
+		}
+	}
+
+	w.run(packages, runPattern(w.failed))
+}
+
+// run runs the tests of the packages, optionally restricted with a go test -run pattern
+func (w *watcher) run(packages []string, pattern string) {
+	if len(packages) == 0 {
+		return
+	}
+
+	w.last = packages
+
+	opts := w.opts
+	opts.pattern = packages
+	opts.goTest = nil
+	if pattern != "" {
+		opts.goTest = []string{"-run", pattern}
+	}
+
+	_, _ = fmt.Fprintf(w.stdio.Stdout, "\n%s running %d packages%s\n", time.Now().Format(time.TimeOnly), len(packages), describeFilters(opts))
+
+	results, err := goTest(w.stdio, opts)
+
+	// failing tests are reported in the summary, only report errors running go test
+	var exitErr *ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		_, _ = fmt.Fprintln(w.stdio.Stderr, "odize:", err)
+	}
+
+	printFailures(w.stdio.Stdout, results)
+	groups, _ := scanPackages(w.packagesByPath(packages))
+	printSummary(w.stdio.Stdout, summarise(groups, results))
+
+	w.failed = failedLeafTests(results)
+}
+
+// refreshPackages resolves the watched packages and snapshots their files
+func (w *watcher) refreshPackages() error {
+	packages, err := listPackages(w.stdio.Dir, w.opts.pattern)
+	if err != nil {
+		return err
+	}
+
+	w.packages = packages
+	w.roots = patternRoots(w.stdio.Dir, w.opts.pattern, packages)
+	w.files, err = snapshotDirs(w.roots, w.dirs())
+
+	return err
+}
+
+func (w *watcher) importPaths() []string {
+	paths := make([]string, 0, len(w.packages))
+	for _, pkg := range w.packages {
+		paths = append(paths, pkg.ImportPath)
+	}
+
+	return paths
+}
+
+func (w *watcher) dirs() []string {
+	dirs := make([]string, 0, len(w.packages))
+	for _, pkg := range w.packages {
+		dirs = append(dirs, pkg.Dir)
+	}
+
+	return dirs
+}
+
+func (w *watcher) packagesByPath(paths []string) []Package {
+	var packages []Package
+	for _, pkg := range w.packages {
+		if slices.Contains(paths, pkg.ImportPath) {
+			packages = append(packages, pkg)
+		}
+	}
+
+	return packages
+}
+
+// patternRoots returns the directory each recursive pattern matches packages within, e.g. ./internal for ./internal/...
+// Import path patterns resolve to the directory shared by the packages they match.
+func patternRoots(dir string, patterns []string, packages []Package) []string {
+	var roots []string
+
+	for _, pattern := range patterns {
+		prefix, ok := strings.CutSuffix(pattern, "...")
+		if !ok {
+			continue
+		}
+
+		prefix = strings.TrimSuffix(prefix, "/")
+
+		var root string
+		switch {
+		case filepath.IsAbs(prefix):
+			root = prefix
+		case build.IsLocalImport(prefix):
+			root = filepath.Join(dir, prefix)
+		default:
+			root = packagesDir(prefix, packages)
+		}
+
+		if root != "" && !slices.Contains(roots, root) {
+			roots = append(roots, root)
+		}
+	}
+
+	return roots
+}
+
+// packagesDir returns the deepest directory containing every package within the import path
+func packagesDir(importPath string, packages []Package) string {
+	root := ""
+	for _, pkg := range packages {
+		if importPath != "" && pkg.ImportPath != importPath && !strings.HasPrefix(pkg.ImportPath, importPath+"/") {
+			continue
+		}
+
+		dir := filepath.Clean(pkg.Dir)
+		for root != "" && dir != root && !strings.HasPrefix(dir, root+string(filepath.Separator)) {
+			root = filepath.Dir(root)
+		}
+
+		if root == "" {
+			root = dir
+		}
+	}
+
+	return root
+}
+
+// snapshotDirs records the Go files and go.mod within each root and its sub directories, and within each of dirs excluding sub directories.
+// Directories skipped by go list, such as testdata, vendor and hidden directories, are not walked. Missing directories are skipped, their files are reported as removed.
+func snapshotDirs(roots []string, dirs []string) (snapshot, error) {
+	files := snapshot{}
+
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() {
+				if path != root && isSkippedDir(entry.Name()) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			return addFile(files, path, entry)
+		})
+		if err != nil {
+			return files, err
+		}
+	}
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return files, err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			if err := addFile(files, filepath.Join(dir, entry.Name()), entry); err != nil {
+				return files, err
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// addFile records the file if it is watched, ignoring files removed since the directory was read
+func addFile(files snapshot, path string, entry fs.DirEntry) error {
+	if !isWatched(entry) {
+		return nil
+	}
+
+	info, err := entry.Info()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	files[filepath.Clean(path)] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+
+	return nil
+}
+
+// isSkippedDir checks if go list ignores the directory when matching ./... patterns
+func isSkippedDir(name string) bool {
+	return name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func isWatched(entry fs.DirEntry) bool {
+	return strings.HasSuffix(entry.Name(), ".go") || entry.Name() == "go.mod"
+}
+
+// changedFiles returns files that were added, removed or modified between snapshots
+func changedFiles(previous snapshot, current snapshot) []string {
+	var changed []string
+
+	for path, stamp := range current {
+		if before, ok := previous[path]; !ok || before != stamp {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	slices.Sort(changed)
+	return changed
+}
+
+// packagesFor maps changed files to the import paths of the packages in the same directory
+func packagesFor(changed []string, packages []Package) []string {
+	var result []string
+
+	for _, dir := range uniqueDirs(changed) {
+		for _, pkg := range packages {
+			if filepath.Clean(pkg.Dir) == dir && !slices.Contains(result, pkg.ImportPath) {
+				result = append(result, pkg.ImportPath)
+			}
+		}
+	}
+
+	return result
+}
+
+func uniqueDirs(files []string) []string {
+	var dirs []string
+	for _, file := range files {
+		if dir := filepath.Dir(file); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// runPattern builds a go test -run pattern matching the tests, one alternation per level of subtests
+func runPattern(results []testResult) string {
+	var levels [][]string
+	for _, result := range results {
+		for i, name := range strings.Split(result.Test, "/") {
+			if i == len(levels) {
+				levels = append(levels, nil)
+			}
+
+			if quoted := regexp.QuoteMeta(name); !slices.Contains(levels[i], quoted) {
+				levels[i] = append(levels[i], quoted)
+			}
+		}
+	}
+
+	parts := make([]string, 0, len(levels))
+	for _, names := range levels {
+		parts = append(parts, "^("+strings.Join(names, "|")+")$")
+	}
+
+	return strings.Join(parts, "/")
+}
+
+func describeFilters(opts runOptions) string {
+	var filters []string
+	if opts.tags != "" {
+		filters = append(filters, "tags "+opts.tags)
+	}
+
+	if opts.filter != "" {
+		filters = append(filters, "focus "+opts.filter)
+	}
+
+	if len(opts.goTest) > 0 {
+		filters = append(filters, "failed only")
+	}
+
+	if len(filters) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(filters, ", ") + ")"
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestParseCommand(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should parse command with argument", func(t *testing.T) {
+			odize.AssertEqual(t, watchCommand{name: "t", arg: "unit,db"}, parseCommand(" T  unit,db "))
+		}).
+		Test("should parse empty line as rerun", func(t *testing.T) {
+			odize.AssertEqual(t, watchCommand{}, parseCommand(""))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestChangedFiles(t *testing.T) {
+	now := time.Now()
+	previous := snapshot{
+		"a/a.go": {modTime: now, size: 1},
+		"a/b.go": {modTime: now, size: 1},
+		"b/c.go": {modTime: now, size: 1},
+	}
+	current := snapshot{
+		"a/a.go": {modTime: now, size: 1},
+		"a/b.go": {modTime: now.Add(time.Second), size: 1},
+		"c/d.go": {modTime: now, size: 1},
+	}
+
+	odize.AssertEqual(t, []string{"a/b.go", "b/c.go", "c/d.go"}, changedFiles(previous, current))
+}
+
+func TestSnapshotDirs(t *testing.T) {
+	dir := t.TempDir()
+	odize.AssertNoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a"), 0o600))
+	odize.AssertNoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("docs"), 0o600))
+	odize.AssertNoError(t, os.Mkdir(filepath.Join(dir, "nested.go"), 0o750))
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should record go files in dirs excluding sub directories", func(t *testing.T) {
+			odize.AssertNoError(t, os.WriteFile(filepath.Join(dir, "nested.go", "b.go"), []byte("package b"), 0o600))
+
+			files, err := snapshotDirs(nil, []string{dir})
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, 1, len(files))
+			_, ok := files[filepath.Join(dir, "a.go")]
+			odize.AssertTrue(t, ok)
+		}).
+		Test("should walk sub directories of roots, skipping directories go list ignores", func(t *testing.T) {
+			for _, sub := range []string{"pkg/added", "testdata", ".git", "_build"} {
+				odize.AssertNoError(t, os.MkdirAll(filepath.Join(dir, sub), 0o750))
+				odize.AssertNoError(t, os.WriteFile(filepath.Join(dir, sub, "c.go"), []byte("package c"), 0o600))
+			}
+
+			files, err := snapshotDirs([]string{dir}, nil)
+			odize.AssertNoError(t, err)
+
+			odize.AssertElementsMatch(t, []string{
+				filepath.Join(dir, "a.go"),
+				filepath.Join(dir, "nested.go", "b.go"),
+				filepath.Join(dir, "pkg", "added", "c.go"),
+			}, slices.Collect(maps.Keys(files)))
+		}).
+		Test("should skip missing directories", func(t *testing.T) {
+			missing := filepath.Join(dir, "removed")
+
+			files, err := snapshotDirs([]string{missing}, []string{missing, dir})
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, 1, len(files))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestPatternRoots(t *testing.T) {
+	packages := []Package{
+		{ImportPath: "example.com/mod/internal/a", Dir: "/src/mod/internal/a"},
+		{ImportPath: "example.com/mod/internal/b/c", Dir: "/src/mod/internal/b/c"},
+		{ImportPath: "example.com/other", Dir: "/src/other"},
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should walk from the directory of relative patterns", func(t *testing.T) {
+			odize.AssertEqual(t, []string{"/src/mod", "/src/mod/cmd"}, patternRoots("/src/mod", []string{"./...", "./cmd/...", "./pkg"}, packages))
+		}).
+		Test("should walk from the directory shared by the packages of import path patterns", func(t *testing.T) {
+			odize.AssertEqual(t, []string{"/src/mod/internal"}, patternRoots("/src/mod", []string{"example.com/mod/internal/..."}, packages))
+			odize.AssertEqual(t, []string{"/src"}, patternRoots("/src/mod", []string{"..."}, packages))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestPackagesChanged(t *testing.T) {
+	dir := t.TempDir()
+	w := &watcher{packages: []Package{{ImportPath: "example.com/a", Dir: dir}}}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should not refresh when files of known packages change", func(t *testing.T) {
+			odize.AssertFalse(t, w.packagesChanged([]string{filepath.Join(dir, "a.go")}))
+		}).
+		Test("should refresh when files change outside known packages", func(t *testing.T) {
+			odize.AssertTrue(t, w.packagesChanged([]string{filepath.Join(dir, "added", "b.go")}))
+		}).
+		Test("should refresh when a known package directory is removed", func(t *testing.T) {
+			removed := &watcher{packages: []Package{{ImportPath: "example.com/removed", Dir: filepath.Join(dir, "removed")}}}
+			odize.AssertTrue(t, removed.packagesChanged([]string{filepath.Join(dir, "removed", "a.go")}))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestPackagesFor(t *testing.T) {
+	packages := []Package{
+		{ImportPath: "example.com/a", Dir: "/src/a"},
+		{ImportPath: "example.com/b", Dir: "/src/b"},
+	}
+
+	result := packagesFor([]string{"/src/b/b.go", "/src/b/b_test.go", "/src/c/c.go"}, packages)
+
+	odize.AssertEqual(t, []string{"example.com/b"}, result)
+}
+
+func TestRunPattern(t *testing.T) {
+	pattern := runPattern([]testResult{
+		{Test: "TestUser/should_add"},
+		{Test: "TestUser/should_remove"},
+		{Test: "TestOrder.v2"},
+	})
+
+	odize.AssertEqual(t, `^(TestUser|TestOrder\.v2)$/^(should_add|should_remove)$`, pattern)
+	odize.AssertTrue(t, regexp.MustCompile(`^(TestUser|TestOrder\.v2)$`).MatchString("TestOrder.v2"))
+}
+
+func TestDescribeFilters(t *testing.T) {
+	odize.AssertEqual(t, "", describeFilters(runOptions{}))
+	odize.AssertEqual(t, " (tags unit, focus *user*, failed only)", describeFilters(runOptions{tags: "unit", filter: "*user*", goTest: []string{"-run", "x"}}))
+}
@@ -0,0 +1,139 @@
+package odize
+
+import (
+	"bytes"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// leakSettleTimeout is how long goroutines started by a test have to exit after its cleanups
+	leakSettleTimeout = 500 * time.Millisecond
+	leakPollInterval  = 10 * time.Millisecond
+)
+
+// defaultLeakIgnore are goroutines that are not leaked by the test
+var defaultLeakIgnore = []string{
+	// steps abandoned after a timeout, which is already reported
+	"odize.runStep",
+}
+
+// goroutine - Goroutine parsed from runtime.Stack
+type goroutine struct {
+	id    int
+	stack string
+}
+
+// DetectLeaks - Fail tests that leave goroutines running once AfterEach and the test's cleanups have completed.
+//
+// Goroutines are snapshotted before each test's BeforeEach hook, and compared after its AfterEach hook and cleanups,
+// including fixture teardowns, giving new goroutines a short time to exit. Goroutines with a stack containing any of the ignored function names are not reported,
+// e.g. "net/http.(*persistConn).readLoop".
+//
+// Leak detection compares goroutines across the whole process, do not use with parallel tests.
+func (tg *TestGroup) DetectLeaks(ignore ...string) *TestGroup {
+	tg.detectLeaks = true
+	tg.leakIgnore = append(tg.leakIgnore, ignore...)
+
+	return tg
+}
+
+// checkLeaks fails the test if goroutines not running before the test are still running, once they have had time to settle
+func (tg *TestGroup) checkLeaks(t *testing.T, before map[int]struct{}) {
+	t.Helper()
+
+	if t.Skipped() {
+		return
+	}
+
+	ignore := slices.Concat(defaultLeakIgnore, tg.leakIgnore)
+
+	var leaked []goroutine
+	_, ok := pollUntil(leakSettleTimeout, leakPollInterval, func() bool {
+		leaked = leakedGoroutines(before, ignore)
+		return len(leaked) == 0
+	})
+	if ok {
+		return
+	}
+
+	stacks := make([]string, len(leaked))
+	for i, g := range leaked {
+		stacks[i] = g.stack
+	}
+
+	t.Errorf("found %d leaked goroutines after %s:\n\n%s", len(leaked), leakSettleTimeout, strings.Join(stacks, "\n\n"))
+}
+
+// leakedGoroutines returns goroutines that were not running before, excluding ignored goroutines
+func leakedGoroutines(before map[int]struct{}, ignore []string) []goroutine {
+	var leaked []goroutine
+	for _, g := range goroutines() {
+		if _, ok := before[g.id]; ok {
+			continue
+		}
+
+		if slices.ContainsFunc(ignore, func(fn string) bool { return strings.Contains(g.stack, fn) }) {
+			continue
+		}
+
+		leaked = append(leaked, g)
+	}
+
+	return leaked
+}
+
+// goroutineIDs returns the IDs of running goroutines
+func goroutineIDs() map[int]struct{} {
+	ids := map[int]struct{}{}
+	for _, g := range goroutines() {
+		ids[g.id] = struct{}{}
+	}
+
+	return ids
+}
+
+// goroutines returns running goroutines, excluding the calling goroutine
+func goroutines() []goroutine {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+
+	// the calling goroutine is always listed first
+	blocks := bytes.Split(buf, []byte("\n\n"))
+	result := make([]goroutine, 0, len(blocks))
+	for _, block := range blocks[1:] {
+		if g, ok := parseGoroutine(string(block)); ok {
+			result = append(result, g)
+		}
+	}
+
+	return result
+}
+
+// parseGoroutine parses a goroutine stack, e.g. "goroutine 7 [chan receive]:\nmain.worker()..."
+func parseGoroutine(stack string) (goroutine, bool) {
+	header, _, _ := strings.Cut(stack, "\n")
+	fields := strings.Fields(header)
+	if len(fields) < 2 || fields[0] != "goroutine" {
+		return goroutine{}, false
+	}
+
+	id, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return goroutine{}, false
+	}
+
+	return goroutine{id: id, stack: strings.TrimSpace(stack)}, true
+}
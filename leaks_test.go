@@ -0,0 +1,135 @@
+package odize
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectLeaks(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when goroutines exit", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+			done := make(chan struct{})
+
+			tg.AfterEach(func() {
+				close(done)
+			})
+
+			err := tg.
+				DetectLeaks().
+				Test("should not leak", func(t *testing.T) {
+					go func() {
+						<-done
+					}()
+				}).
+				Run()
+			AssertNoError(t, err)
+		}).
+		Test("should check goroutines after cleanups and fixture teardowns", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			worker := Fixture(tg, func(t testing.TB) (chan struct{}, func()) {
+				stop := make(chan struct{})
+				go func() {
+					<-stop
+				}()
+
+				return stop, func() { close(stop) }
+			})
+
+			err := tg.
+				DetectLeaks().
+				Test("should not leak", func(t *testing.T) {
+					worker.Get(t)
+
+					stop := make(chan struct{})
+					go func() {
+						<-stop
+					}()
+					t.Cleanup(func() { close(stop) })
+				}).
+				Run()
+			AssertNoError(t, err)
+		}).
+		Test("should give goroutines time to settle", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			err := tg.
+				DetectLeaks().
+				Test("should not leak", func(t *testing.T) {
+					go func() {
+						time.Sleep(50 * time.Millisecond)
+					}()
+				}).
+				Run()
+			AssertNoError(t, err)
+		}).
+		Test("should fail expected failure when goroutine leaks", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+			release := make(chan struct{})
+			defer close(release)
+
+			err := tg.
+				DetectLeaks().
+				Test("should leak", func(t *testing.T) {
+					go func() {
+						<-release
+					}()
+				}, Fails()).
+				Run()
+			AssertNoError(t, err)
+		}).
+		Test("should not report ignored goroutines", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+			release := make(chan struct{})
+			defer close(release)
+
+			err := tg.
+				DetectLeaks("odize.blockUntilReleased").
+				Test("should not leak", func(t *testing.T) {
+					go blockUntilReleased(release)
+				}).
+				Run()
+			AssertNoError(t, err)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestLeakedGoroutines(t *testing.T) {
+	release := make(chan struct{})
+	before := goroutineIDs()
+
+	go blockUntilReleased(release)
+
+	// wait for the goroutine to block, its stack only shows the go statement until it starts
+	AssertEventually(t, func() bool {
+		leaked := leakedGoroutines(before, nil)
+		return len(leaked) == 1 && strings.Contains(leaked[0].stack, "odize.blockUntilReleased")
+	}, time.Second, time.Millisecond)
+
+	AssertEqual(t, 0, len(leakedGoroutines(before, []string{"blockUntilReleased"})))
+
+	close(release)
+
+	AssertEventually(t, func() bool {
+		return len(leakedGoroutines(before, nil)) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestParseGoroutine(t *testing.T) {
+	g, ok := parseGoroutine("goroutine 7 [chan receive]:\nmain.worker()\n\t/src/main.go:10 +0x1d\n")
+	AssertTrue(t, ok)
+	AssertEqual(t, 7, g.id)
+	AssertEqual(t, "goroutine 7 [chan receive]:\nmain.worker()\n\t/src/main.go:10 +0x1d", g.stack)
+
+	_, ok = parseGoroutine("not a goroutine")
+	AssertFalse(t, ok)
+}
+
+func blockUntilReleased(release chan struct{}) {
+	<-release
+}
@@ -0,0 +1,341 @@
+package odize
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Mock - Expectations and calls of a mock, held by mocks generated with odize mockgen
+type Mock struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []mockCall
+	unexpected   []string
+}
+
+// Expectation - Expected call to a mock method, created with On
+type Expectation struct {
+	mock    *Mock
+	method  string
+	args    []any
+	returns []any
+	times   int
+	calls   int
+}
+
+// MockMethod - Calls to a single method of a mock, used with the spy assertions
+type MockMethod struct {
+	mock   *Mock
+	method string
+}
+
+// Matcher - Matches a mock argument, used in place of an argument passed to On
+type Matcher struct {
+	description string
+	match       func(arg any) bool
+}
+
+type mockCall struct {
+	method string
+	call   SpyCall
+}
+
+// NewMock - Create a mock
+func NewMock() *Mock {
+	return &Mock{}
+}
+
+// On - Expect a call to the method with args. Arguments are compared with AssertEqual semantics, unless they are a Matcher.
+// Variadic arguments are expected as a single slice.
+//
+// An expectation is met when called at least once, or exactly the number of times set by Times.
+//
+// Example:
+//
+//	store.On("Get", odize.Any(), "user-1").Return(User{Name: "John"}, nil)
+func (m *Mock) On(method string, args ...any) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expectation := &Expectation{
+		mock:   m,
+		method: method,
+		args:   args,
+	}
+	m.expectations = append(m.expectations, expectation)
+
+	return expectation
+}
+
+// Called - Record a call to the method, returning the values of the first matching expectation.
+// Calls without a matching expectation return zero values, and are reported by Verify.
+func (m *Mock) Called(method string, args ...any) []any {
+	caller := ""
+	if _, file, line, ok := runtime.Caller(2); ok {
+		caller = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var returns []any
+	if expectation := m.match(method, args); expectation != nil {
+		expectation.calls++
+		returns = expectation.returns
+	} else {
+		m.unexpected = append(m.unexpected, fmt.Sprintf("unexpected %s at %s", formatMockCall(method, args), caller))
+	}
+
+	m.calls = append(m.calls, mockCall{
+		method: method,
+		call: SpyCall{
+			Args:    args,
+			Returns: returns,
+			Caller:  caller,
+		},
+	})
+
+	return returns
+}
+
+// Verify - Fail the test if an expectation was not met, or the mock was called without a matching expectation
+//
+// Example:
+//
+//	store.Verify(t)
+func (m *Mock) Verify(t testing.TB) {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expected, got []string
+	for _, expectation := range m.expectations {
+		if expectation.met() {
+			continue
+		}
+
+		call := formatMockCall(expectation.method, expectation.args)
+		expected = append(expected, fmt.Sprintf("%s: %s", call, formatTimes(expectation.times)))
+		got = append(got, fmt.Sprintf("%s: %d calls", call, expectation.calls))
+	}
+
+	got = append(got, m.unexpected...)
+
+	if len(got) > 0 {
+		log(t, decorateDiff(strings.Join(expected, "\n"), strings.Join(got, "\n")))
+	}
+}
+
+// Method - Calls to a single method, used with the spy assertions
+//
+// Example:
+//
+//	AssertCalledWith(t, store.Method("Get"), ctx, "user-1")
+func (m *Mock) Method(method string) *MockMethod {
+	return &MockMethod{
+		mock:   m,
+		method: method,
+	}
+}
+
+// CallHistory - Calls made to every method of the mock, in the order they were made
+func (m *Mock) CallHistory() []SpyCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	history := make([]SpyCall, 0, len(m.calls))
+	for _, c := range m.calls {
+		history = append(history, c.call)
+	}
+
+	return history
+}
+
+// Reset - Clear the recorded calls, including the calls counted against each expectation. Expectations are kept.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+	m.unexpected = nil
+	for _, expectation := range m.expectations {
+		expectation.calls = 0
+	}
+}
+
+// match returns the first expectation for the method and args that has not been called the expected number of times
+func (m *Mock) match(method string, args []any) *Expectation {
+	for _, expectation := range m.expectations {
+		if expectation.method != method || !matchArgs(expectation.args, args) {
+			continue
+		}
+
+		if expectation.times == 0 || expectation.calls < expectation.times {
+			return expectation
+		}
+	}
+
+	return nil
+}
+
+// Return - Return the values when the expectation is matched
+func (e *Expectation) Return(values ...any) *Expectation {
+	e.mock.mu.Lock()
+	defer e.mock.mu.Unlock()
+
+	e.returns = values
+
+	return e
+}
+
+// Times - Expect exactly n calls. Further calls fall through to later expectations.
+func (e *Expectation) Times(n int) *Expectation {
+	e.mock.mu.Lock()
+	defer e.mock.mu.Unlock()
+
+	e.times = n
+
+	return e
+}
+
+// Once - Expect exactly 1 call
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// met checks if the expectation was called the expected number of times
+func (e *Expectation) met() bool {
+	if e.times == 0 {
+		return e.calls > 0
+	}
+
+	return e.calls == e.times
+}
+
+// CallHistory - Calls made to the method, in the order they were made
+func (mm *MockMethod) CallHistory() []SpyCall {
+	mm.mock.mu.Lock()
+	defer mm.mock.mu.Unlock()
+
+	var history []SpyCall
+	for _, c := range mm.mock.calls {
+		if c.method == mm.method {
+			history = append(history, c.call)
+		}
+	}
+
+	return history
+}
+
+// Reset - Clear the recorded calls of the whole mock
+func (mm *MockMethod) Reset() {
+	mm.mock.Reset()
+}
+
+// MockResult - Return value i of a mock call as T, or the zero value if it was not set. Used by generated mocks.
+func MockResult[T any](results []any, i int) T {
+	var zero T
+	if i >= len(results) || results[i] == nil {
+		return zero
+	}
+
+	value, ok := results[i].(T)
+	if !ok {
+		panic(fmt.Sprintf("odize: mock return value %d must be %s, got %T", i, reflect.TypeFor[T](), results[i]))
+	}
+
+	return value
+}
+
+// Any - Match any argument
+func Any() Matcher {
+	return Matcher{
+		description: "Any()",
+		match: func(any) bool {
+			return true
+		},
+	}
+}
+
+// AnyOfType - Match any argument of type T
+func AnyOfType[T any]() Matcher {
+	return Matcher{
+		description: fmt.Sprintf("AnyOfType[%s]()", reflect.TypeFor[T]()),
+		match: func(arg any) bool {
+			_, ok := arg.(T)
+			return ok
+		},
+	}
+}
+
+// MatchedBy - Match arguments of type T that fn returns true for
+//
+// Example:
+//
+//	store.On("Save", odize.MatchedBy(func(u User) bool { return u.Name != "" }))
+func MatchedBy[T any](fn func(T) bool) Matcher {
+	return Matcher{
+		description: fmt.Sprintf("MatchedBy[%s]()", reflect.TypeFor[T]()),
+		match: func(arg any) bool {
+			value, ok := arg.(T)
+			return ok && fn(value)
+		},
+	}
+}
+
+// String - Description of the matcher
+func (m Matcher) String() string {
+	return m.description
+}
+
+// matchArgs checks each argument is equal, or matched by a Matcher
+func matchArgs(expected []any, actual []any) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	for i, arg := range expected {
+		if matcher, ok := arg.(Matcher); ok {
+			if !matcher.match(actual[i]) {
+				return false
+			}
+
+			continue
+		}
+
+		if !isEqual(arg, actual[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatMockCall formats a call as method(args)
+func formatMockCall(method string, args []any) string {
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		switch value := arg.(type) {
+		case string:
+			formatted[i] = fmt.Sprintf("%q", value)
+		default:
+			formatted[i] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	return fmt.Sprintf("%s(%s)", method, strings.Join(formatted, ", "))
+}
+
+// formatTimes formats the expected number of calls
+func formatTimes(times int) string {
+	if times == 0 {
+		return "at least 1 call"
+	}
+
+	return fmt.Sprintf("%d calls", times)
+}
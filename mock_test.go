@@ -0,0 +1,118 @@
+package odize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMock(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	var mock *Mock
+
+	group.BeforeEach(func() {
+		mock = NewMock()
+	})
+
+	err := group.
+		Test("should return values of matching expectation", func(t *testing.T) {
+			mock.On("Get", "a").Return("value", nil)
+
+			results := mock.Called("Get", "a")
+			AssertEqual(t, "value", MockResult[string](results, 0))
+			AssertNil(t, MockResult[error](results, 1))
+			mock.Verify(t)
+		}).
+		Test("should return zero values without matching expectation", func(t *testing.T) {
+			mock.On("Get", "a").Return("value", nil)
+
+			results := mock.Called("Get", "b")
+			AssertEqual(t, "", MockResult[string](results, 0))
+		}).
+		Test("should match arguments with matchers", func(t *testing.T) {
+			mock.On("Save", Any(), AnyOfType[int](), MatchedBy(func(s string) bool { return strings.HasPrefix(s, "user") })).Return(nil)
+
+			mock.Called("Save", struct{}{}, 1, "user-1")
+			mock.Verify(t)
+
+			AssertFalse(t, matchArgs([]any{AnyOfType[int]()}, []any{"1"}))
+			AssertFalse(t, matchArgs([]any{MatchedBy(func(s string) bool { return true })}, []any{1}))
+		}).
+		Test("should fall through to later expectations once times are met", func(t *testing.T) {
+			errExpected := errors.New("expected")
+			mock.On("Get", "a").Return("first", nil).Once()
+			mock.On("Get", "a").Return("", errExpected)
+
+			AssertEqual(t, "first", MockResult[string](mock.Called("Get", "a"), 0))
+			AssertEqual(t, errExpected, MockResult[error](mock.Called("Get", "a"), 1))
+			mock.Verify(t)
+		}).
+		Test("should record calls for the spy assertions", func(t *testing.T) {
+			mock.On("Get", Any()).Return("value", nil)
+			mock.Called("Get", "a")
+			mock.Called("Put", "b")
+
+			AssertCalledTimes(t, mock, 2)
+			AssertCalledTimes(t, mock.Method("Get"), 1)
+			AssertCalledWith(t, mock.Method("Put"), "b")
+			AssertEqual(t, []any{"value", nil}, mock.Method("Get").CallHistory()[0].Returns)
+		}).
+		Test("should reset calls and keep expectations", func(t *testing.T) {
+			mock.On("Get", "a").Return("value", nil)
+			mock.Called("Get", "a")
+			mock.Called("Put", "b")
+
+			mock.Reset()
+
+			AssertNotCalled(t, mock)
+			AssertEqual(t, "value", MockResult[string](mock.Called("Get", "a"), 0))
+			mock.Verify(t)
+		}).
+		Test("should fail verify when expectation is not met", func(t *testing.T) {
+			mock.On("Get", "a").Return("value", nil)
+			mock.On("Put", Any()).Times(2)
+			mock.Called("Put", "b")
+
+			c := runCollectAttempt(t, func(c *Collect) {
+				mock.Verify(c)
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), `Get("a"): at least 1 call`))
+			AssertTrue(t, strings.Contains(c.String(), `Get("a"): 0 calls`))
+			AssertTrue(t, strings.Contains(c.String(), `Put(Any()): 2 calls`))
+			AssertTrue(t, strings.Contains(c.String(), `Put(Any()): 1 calls`))
+		}).
+		Test("should fail verify when called without expectation", func(t *testing.T) {
+			// generated mocks call Called from the mocked method
+			get := func(id string) {
+				mock.Called("Get", id)
+			}
+			get("a")
+
+			c := runCollectAttempt(t, func(c *Collect) {
+				mock.Verify(c)
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), `unexpected Get("a") at `))
+			AssertTrue(t, strings.Contains(c.String(), "mock_test.go:"))
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestMockResult(t *testing.T) {
+	AssertEqual(t, 0, MockResult[int](nil, 0))
+	AssertEqual(t, 1, MockResult[int]([]any{1}, 0))
+
+	c := runCollectAttempt(t, func(c *Collect) {
+		defer func() {
+			if recover() == nil {
+				c.Error("expected panic")
+			}
+		}()
+
+		MockResult[int]([]any{"1"}, 0)
+	})
+	AssertFalse(t, c.Failed())
+}
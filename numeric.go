@@ -0,0 +1,164 @@
+package odize
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Number - Integer and floating point types, used by the tolerance assertions
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Direction - Order of the values checked by AssertMonotonic
+type Direction int
+
+const (
+	// Increasing - Each value is greater than or equal to the previous value
+	Increasing Direction = iota
+	// Decreasing - Each value is less than or equal to the previous value
+	Decreasing
+)
+
+// AssertGreater checks actual is greater than threshold. NaN values always fail.
+//
+// Example:
+//
+//	AssertGreater(t, balance, 0)
+func AssertGreater[T cmp.Ordered](t testing.TB, actual T, threshold T) {
+	t.Helper()
+
+	if isNaN(actual) || isNaN(threshold) || cmp.Compare(actual, threshold) <= 0 {
+		log(t, fmt.Sprintf("%v is not greater than %v", formatValue(actual), formatValue(threshold))+decorateDiff(fmt.Sprintf("> %v", formatValue(threshold)), formatValue(actual)))
+	}
+}
+
+// AssertLess checks actual is less than threshold. NaN values always fail.
+//
+// Example:
+//
+//	AssertLess(t, latency, 100*time.Millisecond)
+func AssertLess[T cmp.Ordered](t testing.TB, actual T, threshold T) {
+	t.Helper()
+
+	if isNaN(actual) || isNaN(threshold) || cmp.Compare(actual, threshold) >= 0 {
+		log(t, fmt.Sprintf("%v is not less than %v", formatValue(actual), formatValue(threshold))+decorateDiff(fmt.Sprintf("< %v", formatValue(threshold)), formatValue(actual)))
+	}
+}
+
+// AssertBetween checks actual is between minimum and maximum, inclusive. NaN values always fail.
+//
+// Example:
+//
+//	AssertBetween(t, rate, 0.01, 0.05)
+func AssertBetween[T cmp.Ordered](t testing.TB, actual T, minimum T, maximum T) {
+	t.Helper()
+
+	if isNaN(actual) || isNaN(minimum) || isNaN(maximum) || cmp.Compare(actual, minimum) < 0 || cmp.Compare(actual, maximum) > 0 {
+		log(t, fmt.Sprintf("%v is not between %v and %v", formatValue(actual), formatValue(minimum), formatValue(maximum))+decorateDiff(fmt.Sprintf("[%v, %v]", formatValue(minimum), formatValue(maximum)), formatValue(actual)))
+	}
+}
+
+// AssertInDelta checks the absolute difference between expected and actual is at most delta. NaN values always fail.
+//
+// Example:
+//
+//	AssertInDelta(t, 10.35, total, 0.005)
+func AssertInDelta[T Number](t testing.TB, expected T, actual T, delta T) {
+	t.Helper()
+
+	difference := math.Abs(float64(expected) - float64(actual))
+	if math.IsNaN(difference) || math.IsNaN(float64(delta)) || difference > float64(delta) {
+		log(t, fmt.Sprintf("difference %v exceeds delta %v", difference, delta)+decorateDiff(fmt.Sprintf("%v ± %v", expected, delta), actual))
+	}
+}
+
+// AssertInEpsilon checks the relative error between expected and actual, |expected - actual| / |expected|, is at most epsilon.
+// If expected is 0, actual must also be 0. NaN values always fail.
+//
+// Example:
+//
+//	AssertInEpsilon(t, 1000.0, interest, 0.001)
+func AssertInEpsilon[T Number](t testing.TB, expected T, actual T, epsilon float64) {
+	t.Helper()
+
+	relative, ok := relativeError(float64(expected), float64(actual))
+	if !ok {
+		log(t, fmt.Sprintf("unable to calculate the relative error of %v against %v", actual, expected)+decorateDiff(expected, actual))
+		return
+	}
+
+	if math.IsNaN(epsilon) || relative > epsilon {
+		log(t, fmt.Sprintf("relative error %v exceeds epsilon %v", relative, epsilon)+decorateDiff(fmt.Sprintf("%v ± %v%%", expected, epsilon*100), actual))
+	}
+}
+
+// AssertMonotonic checks the values are in the direction, allowing equal neighbours. Failures report the index of the first value out of order.
+//
+// Example:
+//
+//	AssertMonotonic(t, timestamps, Increasing)
+func AssertMonotonic[T cmp.Ordered](t testing.TB, values []T, direction Direction) {
+	t.Helper()
+
+	for i := 1; i < len(values); i++ {
+		order := cmp.Compare(values[i], values[i-1])
+		if (direction == Increasing && order < 0) || (direction == Decreasing && order > 0) {
+			log(t, fmt.Sprintf("values are not %s at index %d: %v follows %v", direction, i, formatValue(values[i]), formatValue(values[i-1]))+decorateDiff(direction, formatValue(values)))
+			return
+		}
+	}
+}
+
+// AssertSorted checks the values are sorted by less, allowing equal neighbours. Failures report the index of the first value out of order.
+//
+// Example:
+//
+//	AssertSorted(t, users, func(a, b User) bool { return a.Name < b.Name })
+func AssertSorted[T any](t testing.TB, values []T, less func(a, b T) bool) {
+	t.Helper()
+
+	for i := 1; i < len(values); i++ {
+		if less(values[i], values[i-1]) {
+			log(t, fmt.Sprintf("values are not sorted at index %d: %v is less than %v", i, formatValue(values[i]), formatValue(values[i-1]))+decorateDiff("sorted", formatValue(values)))
+			return
+		}
+	}
+}
+
+// String - Name of the direction
+func (d Direction) String() string {
+	switch d {
+	case Increasing:
+		return "increasing"
+	case Decreasing:
+		return "decreasing"
+	default:
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+}
+
+// isNaN checks if v is a floating point NaN, which cmp.Compare orders before every other value
+func isNaN[T cmp.Ordered](v T) bool {
+	value := reflect.ValueOf(v)
+
+	return value.CanFloat() && math.IsNaN(value.Float())
+}
+
+// relativeError returns |expected - actual| / |expected|. Returns false if either value is NaN, or expected is 0 and actual is not.
+func relativeError(expected float64, actual float64) (float64, bool) {
+	if math.IsNaN(expected) || math.IsNaN(actual) {
+		return 0, false
+	}
+
+	if expected == 0 {
+		return 0, actual == 0
+	}
+
+	return math.Abs(expected-actual) / math.Abs(expected), true
+}
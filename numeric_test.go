@@ -0,0 +1,171 @@
+package odize
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAssertGreaterLess(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when greater", func(t *testing.T) {
+			AssertGreater(t, 2, 1)
+			AssertGreater(t, "b", "a")
+			AssertGreater(t, time.Second, time.Millisecond)
+		}).
+		Test("should fail when equal or less", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertGreater(c, 1, 1)
+			}, "1 is not greater than 1")
+			assertFails(t, func(c *Collect) {
+				AssertGreater(c, 0.5, 1.5)
+			}, "0.5 is not greater than 1.5")
+		}).
+		Test("should pass when less", func(t *testing.T) {
+			AssertLess(t, 1, 2)
+			AssertLess(t, -1.5, 0)
+		}).
+		Test("should fail when equal or greater", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertLess(c, "b", "a")
+			}, `"b" is not less than "a"`)
+		}).
+		Test("should pass when between inclusive", func(t *testing.T) {
+			AssertBetween(t, 1, 1, 3)
+			AssertBetween(t, 2, 1, 3)
+			AssertBetween(t, 3, 1, 3)
+		}).
+		Test("should fail when outside range", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertBetween(c, 4, 1, 3)
+			}, "4 is not between 1 and 3", "[1, 3]")
+		}).
+		Test("should fail with NaN", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertLess(c, math.NaN(), 1.0)
+			}, "NaN is not less than 1")
+			assertFails(t, func(c *Collect) {
+				AssertLess(c, 1.0, math.NaN())
+			}, "1 is not less than NaN")
+			assertFails(t, func(c *Collect) {
+				AssertGreater(c, math.NaN(), 1.0)
+			}, "NaN is not greater than 1")
+			assertFails(t, func(c *Collect) {
+				AssertBetween(c, math.NaN(), 0.0, 1.0)
+			}, "NaN is not between 0 and 1")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertInDelta(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass within delta", func(t *testing.T) {
+			AssertInDelta(t, 0.3, 0.1+0.2, 1e-9)
+			AssertInDelta(t, 10, 12, 2)
+			AssertInDelta(t, uint(12), uint(10), 2)
+		}).
+		Test("should fail outside delta", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertInDelta(c, 10.0, 10.5, 0.1)
+			}, "difference 0.5 exceeds delta 0.1", "10 ± 0.1")
+		}).
+		Test("should fail with NaN", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertInDelta(c, math.NaN(), 1, 1)
+			}, "exceeds delta")
+		}).
+		Test("should fail with NaN actual or delta", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertInDelta(c, 1, math.NaN(), 1)
+			}, "exceeds delta")
+			assertFails(t, func(c *Collect) {
+				AssertInDelta(c, 1.0, 1.0, math.NaN())
+			}, "exceeds delta NaN")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertInEpsilon(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass within relative error", func(t *testing.T) {
+			AssertInEpsilon(t, 1000.0, 1000.5, 0.001)
+			AssertInEpsilon(t, -100, -101, 0.01)
+			AssertInEpsilon(t, 0.0, 0.0, 0.001)
+		}).
+		Test("should fail outside relative error", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertInEpsilon(c, 100.0, 110.0, 0.01)
+			}, "relative error 0.1 exceeds epsilon 0.01")
+		}).
+		Test("should fail when expected is 0 and actual is not", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertInEpsilon(c, 0.0, 0.1, 0.5)
+			}, "unable to calculate the relative error of 0.1 against 0")
+		}).
+		Test("should fail with NaN", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertInEpsilon(c, 1, math.NaN(), 0.5)
+			}, "unable to calculate the relative error")
+		}).
+		Test("should fail with NaN epsilon", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertInEpsilon(c, 1.0, 1.0, math.NaN())
+			}, "exceeds epsilon NaN")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertMonotonic(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when increasing", func(t *testing.T) {
+			AssertMonotonic(t, []int{1, 2, 2, 5}, Increasing)
+			AssertMonotonic(t, []int{}, Increasing)
+		}).
+		Test("should pass when decreasing", func(t *testing.T) {
+			AssertMonotonic(t, []string{"c", "b", "b", "a"}, Decreasing)
+		}).
+		Test("should report the violating index", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertMonotonic(c, []int{1, 3, 2, 4}, Increasing)
+			}, "values are not increasing at index 2: 2 follows 3")
+			assertFails(t, func(c *Collect) {
+				AssertMonotonic(c, []float64{3, 2, 2.5}, Decreasing)
+			}, "values are not decreasing at index 2: 2.5 follows 2")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertSorted(t *testing.T) {
+	type user struct {
+		name string
+	}
+
+	byName := func(a, b user) bool { return a.name < b.name }
+
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when sorted", func(t *testing.T) {
+			AssertSorted(t, []user{{"a"}, {"b"}, {"b"}}, byName)
+			AssertSorted(t, nil, byName)
+		}).
+		Test("should report the violating index", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertSorted(c, []user{{"a"}, {"c"}, {"b"}}, byName)
+			}, "values are not sorted at index 2: {b} is less than {c}")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
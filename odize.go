@@ -4,8 +4,11 @@ package odize
 import (
 	"errors"
 	"fmt"
+	"runtime/debug"
 	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/code-gorilla-au/env"
 )
@@ -15,6 +18,17 @@ const (
 	ODIZE_TAGS = "ODIZE_TAGS"
 	// ENV_CI ENV variable declared in pipelines such as Github Actions
 	ENV_CI = "CI"
+	// ODIZE_UPDATE_GOLDEN is the environment variable that is used to write golden files instead of comparing them
+	ODIZE_UPDATE_GOLDEN = "ODIZE_UPDATE_GOLDEN"
+	// ODIZE_SHUFFLE is the environment variable that is used to shuffle tests, either "on" for a random seed or a seed to reproduce an order
+	ODIZE_SHUFFLE = "ODIZE_SHUFFLE"
+	// ODIZE_SHARD is the environment variable that is used to run a subset of tests, in the format "index/total" e.g. "1/4"
+	ODIZE_SHARD = "ODIZE_SHARD"
+	// ODIZE_FILTER is the environment variable that is used to run tests by name, either a glob e.g. "*user*" or a regex wrapped in slashes e.g. "/^should (add|remove)/"
+	// A filter that matches no test in the package is only reported when the package uses Main.
+	ODIZE_FILTER = "ODIZE_FILTER"
+	// ODIZE_RERUN_FAILED is the environment variable that is used to only run tests that failed in the previous run
+	ODIZE_RERUN_FAILED = "ODIZE_RERUN_FAILED"
 )
 
 var (
@@ -31,12 +45,18 @@ func NewGroup(t *testing.T, tags *[]string) *TestGroup {
 	}
 
 	tg := &TestGroup{
-		t:         t,
-		groupTags: *groupTags,
-		envTags:   env.GetAsSlice(ODIZE_TAGS, ","),
-		registry:  []TestRegistryEntry{},
-		cache:     map[string]struct{}{},
-		isCIEnv:   env.GetAsBool(ENV_CI),
+		t:              t,
+		groupTags:      suite.groupTags(*groupTags),
+		envTags:        env.GetAsSlice(ODIZE_TAGS, ","),
+		registry:       []TestRegistryEntry{},
+		cache:          map[string]struct{}{},
+		isCIEnv:        env.GetAsBool(ENV_CI),
+		envShuffle:     env.GetAsString(ODIZE_SHUFFLE),
+		envShard:       env.GetAsString(ODIZE_SHARD),
+		envFilter:      env.GetAsString(ODIZE_FILTER),
+		rerunFailed:    env.GetAsBool(ODIZE_RERUN_FAILED),
+		lastFailedPath: lastFailedPath,
+		pkg:            callerPackage(),
 	}
 
 	tg.registerCleanupTasks()
@@ -58,6 +78,23 @@ func (tg *TestGroup) Test(name string, testFn TestFn, options ...TestFuncOpts) *
 	return tg
 }
 
+// Todo - Add a placeholder for a test that has not been written yet.
+// Todo tests are skipped with a TODO marker, and counted in the package report.
+func (tg *TestGroup) Todo(name string) *TestGroup {
+	err := tg.registerEntry(TestRegistryEntry{
+		name: name,
+		fn: func(t *testing.T) {
+			t.Skipf("TODO: %s", name)
+		},
+		status: StatusTodo,
+	})
+	if err != nil {
+		tg.errors.Append(err)
+	}
+
+	return tg
+}
+
 // BeforeEach - Run before each test
 func (tg *TestGroup) BeforeEach(fn func()) {
 	tg.beforeEach = fn
@@ -78,14 +115,34 @@ func (tg *TestGroup) AfterAll(fn func()) {
 	tg.afterAll = fn
 }
 
+// Timeout - Set the default timeout for each test within the group, including its BeforeEach and AfterEach hooks.
+//
+// A test that exceeds the timeout fails, and the group continues with the remaining tests.
+// Use the Timeout test option to override the group default for a single test.
+func (tg *TestGroup) Timeout(d time.Duration) *TestGroup {
+	tg.timeout = d
+	return tg
+}
+
+// Retry - Set the default number of times a failing test within the group is retried.
+// Tests that only pass after a retry are reported as flaky in the output, and in the error returned by Run.
+// Parallel tests complete after Run returns, so flaky parallel tests fail the group once its tests complete.
+// Attempts before the final attempt run in a child process of the test binary, which runs TestMain again.
+// Use the Retry test option to override the group default for a single test.
+func (tg *TestGroup) Retry(n int) *TestGroup {
+	tg.retry = n
+	return tg
+}
+
 // Run - Run all tests within a group.If the ODIZE_TAGS environment variable is set, then only tests with matching tags will be run.
 //
 // If errors are encountered, tests will not run.
 func (tg *TestGroup) Run() error {
 	tg.t.Helper()
 
+	tg.started = true
+
 	if tg.errors.Len() > 0 {
-		tg.complete = true
 		return &tg.errors
 	}
 
@@ -99,38 +156,335 @@ func (tg *TestGroup) Run() error {
 
 	tg.beforeAll()
 
-	entries, err := filterExecutableTests(tg.isCIEnv, tg.registry)
+	entries, err := tg.executableEntries()
 	if err != nil {
 		// Stop Run, suite is in an invalid state
-		tg.complete = true
 		return fmt.Errorf("test group \"%s\" error: %w", tg.t.Name(), err)
 	}
 
+	// parallel tests complete after Run returns, so results are reported once every test in the group has completed
+	tg.t.Cleanup(func() {
+		tg.resultsMu.Lock()
+		defer tg.resultsMu.Unlock()
+
+		for _, err := range tg.flaky[tg.flakyReturned:] {
+			tg.t.Error(err)
+		}
+
+		if attemptTest != "" {
+			return
+		}
+
+		tg.recordFailures(tg.ran, tg.failed)
+	})
+
 	for _, entry := range entries {
-		tg.beforeEach()
-		tg.t.Run(entry.name, entry.fn)
-		tg.afterEach()
+		tg.runEntry(entry)
 	}
 
 	tg.afterAll()
 
-	tg.complete = true
+	tg.resultsMu.Lock()
+	for _, err := range tg.flaky {
+		tg.errors.Append(err)
+	}
+	tg.flakyReturned = len(tg.flaky)
+	tg.resultsMu.Unlock()
+
+	if tg.errors.Len() > 0 {
+		return &tg.errors
+	}
 
 	return nil
 }
 
+// executableEntries resolves the entries to run, applying test options, name filters, previous failures, sharding and shuffling
+func (tg *TestGroup) executableEntries() ([]TestRegistryEntry, error) {
+	tg.t.Helper()
+
+	entries, err := filterExecutableTests(tg.isCIEnv, tg.registry)
+	if err != nil {
+		return entries, err
+	}
+
+	entries, err = tg.filterNamedTests(entries)
+	if err != nil {
+		return entries, err
+	}
+
+	entries, err = tg.filterRerunFailedTests(entries)
+	if err != nil {
+		return entries, err
+	}
+
+	entries, err = tg.filterShardTests(entries)
+	if err != nil {
+		return entries, err
+	}
+
+	if err = tg.shuffleEntries(entries); err != nil {
+		return entries, err
+	}
+
+	return entries, nil
+}
+
+// runEntry runs a test along with the BeforeEach and AfterEach hooks, enforcing the test timeout and retries if set.
+//
+// Go does not allow a failed *testing.T to recover, so retried attempts before the final attempt, and tests expected to fail,
+// run in a child process of the test binary. The final attempt always runs against the test's own *testing.T, reporting any failures as normal.
+// The result is recorded once the test completes, including tests that call t.Parallel.
+func (tg *TestGroup) runEntry(entry TestRegistryEntry) {
+	timeout := entry.options.Timeout
+	if timeout == 0 {
+		timeout = tg.timeout
+	}
+
+	retries := entry.options.Retry
+	if retries == 0 {
+		retries = tg.retry
+	}
+
+	tg.t.Run(entry.name, func(t *testing.T) {
+		t.Helper()
+
+		if isAttemptChild(t) {
+			tg.runChildAttempt(t, entry, timeout)
+			return
+		}
+
+		if !entry.skipped {
+			// registered first so it runs after every other cleanup
+			t.Cleanup(func() {
+				tg.recordResult(entry.name, t.Failed())
+			})
+		}
+
+		switch entry.status {
+		case StatusTodo:
+			suite.recordStatus(StatusTodo, t.Name())
+			entry.fn(t)
+		case StatusFails:
+			tg.runExpectedFailure(t, entry)
+		default:
+			tg.runWithRetries(t, entry, timeout, retries)
+		}
+	})
+}
+
+// runWithRetries runs the test, running attempts before the final attempt in a child process
+func (tg *TestGroup) runWithRetries(t *testing.T, entry TestRegistryEntry, timeout time.Duration, retries int) {
+	t.Helper()
+
+	attempts := retries + 1
+
+	var failures []string
+	for attempt := 1; attempt < attempts; attempt++ {
+		result, err := runIsolatedAttempt(t)
+		if err != nil {
+			t.Fatalf("test \"%s\" attempt %d of %d: %v", t.Name(), attempt, attempts, err)
+		}
+
+		if result.Skipped {
+			break
+		}
+
+		if result.passed() {
+			tg.recordFlaky(t, entry.name, attempt, attempts, failures)
+			return
+		}
+
+		failure := fmt.Sprintf("attempt %d of %d failed:\n%s", attempt, attempts, result.output)
+		failures = append(failures, failure)
+		t.Log(failure)
+	}
+
+	tg.runAttempt(t, entry, timeout)
+
+	if !t.Failed() && !t.Skipped() {
+		tg.recordFlaky(t, entry.name, attempts, attempts, failures)
+	}
+}
+
+// runExpectedFailure runs a test marked with the Fails option in a child process, inverting the outcome.
+// The test passes when the body fails, and fails when the body unexpectedly passes, times out or does not complete.
+func (tg *TestGroup) runExpectedFailure(t *testing.T, entry TestRegistryEntry) {
+	t.Helper()
+
+	result, err := runIsolatedAttempt(t)
+	if err != nil {
+		t.Fatalf("test \"%s\": %v", t.Name(), err)
+	}
+
+	switch {
+	case result.Skipped:
+		t.Skip("skipping expected failure ", entry.name)
+	case !result.Ran:
+		t.Errorf("test \"%s\" is marked with Fails() but did not complete:\n%s", t.Name(), result.output)
+		return
+	case result.TimedOut:
+		t.Errorf("test \"%s\" is marked with Fails() but timed out, a known bug should fail rather than hang:\n%s", t.Name(), result.output)
+		return
+	case !result.Failed:
+		t.Errorf("test \"%s\" is marked with Fails() but unexpectedly passed, remove the Fails() option if the bug is fixed", t.Name())
+		return
+	}
+
+	suite.recordStatus(StatusFails, t.Name())
+	t.Log("failed as expected")
+}
+
+// runAttempt runs a single attempt of a test, along with the BeforeEach and AfterEach hooks.
+// If leak detection is enabled, goroutines started by the attempt that are still running after its cleanups fail the attempt.
+//
+// Without a timeout the steps run on the test goroutine, so t.FailNow and panics are handled by the testing package as normal.
+// With a timeout, BeforeEach, the test body and AfterEach share a single deadline. Returns false if a step timed out.
+func (tg *TestGroup) runAttempt(t *testing.T, entry TestRegistryEntry, timeout time.Duration) bool {
+	t.Helper()
+
+	if tg.detectLeaks {
+		before := goroutineIDs()
+
+		// registered first so it runs after every other cleanup, including fixture teardowns
+		t.Cleanup(func() {
+			tg.checkLeaks(t, before)
+		})
+	}
+
+	if timeout == 0 {
+		// deferred so AfterEach runs if the test exits early with t.FailNow
+		defer tg.afterEach()
+
+		tg.beforeEach()
+		entry.fn(t)
+
+		return true
+	}
+
+	start := time.Now()
+
+	if !runStep(t, "BeforeEach", start, timeout, tg.beforeEach) {
+		return false
+	}
+
+	completed := runStep(t, "test body", start, timeout, func() {
+		entry.fn(t)
+	})
+	if !completed {
+		// return straight away so the test's context is cancelled, then run AfterEach with the cleanups, allowing it a full timeout to clean up
+		t.Cleanup(func() {
+			runStep(t, "AfterEach", time.Now(), timeout, tg.afterEach)
+		})
+
+		return false
+	}
+
+	return runStep(t, "AfterEach", start, timeout, tg.afterEach)
+}
+
+// recordResult records a test that ran, and whether it failed
+func (tg *TestGroup) recordResult(name string, failed bool) {
+	tg.resultsMu.Lock()
+	defer tg.resultsMu.Unlock()
+
+	tg.ran = append(tg.ran, name)
+	if failed {
+		tg.failed = append(tg.failed, name)
+	}
+}
+
+// recordFlaky marks a test as flaky if it only passed after a retry, replaying the output of the failed attempts
+func (tg *TestGroup) recordFlaky(t *testing.T, name string, attempt int, attempts int, failures []string) {
+	t.Helper()
+
+	if attempt == 1 {
+		return
+	}
+
+	t.Logf("flaky: passed on attempt %d of %d", attempt, attempts)
+	suite.recordFlaky(t.Name(), failures)
+
+	tg.resultsMu.Lock()
+	defer tg.resultsMu.Unlock()
+
+	tg.flaky = append(tg.flaky, fmt.Errorf("%w: \"%s\" passed on attempt %d of %d", ErrTestFlaky, name, attempt, attempts))
+}
+
+// runStep runs a step of a test on its own goroutine, failing the test if the step does not finish within timeout of start.
+// The test continues if the step calls t.FailNow or panics, panics are reported as failures with their stack.
+// A timeout of 0 runs the step on the calling goroutine. Returns false if the step timed out.
+//
+// A step that exceeds the timeout is abandoned. Once the test completes, reporting to it from the abandoned step panics,
+// so the panic is recovered and the rest of the step is discarded.
+func runStep(t testing.TB, step string, start time.Time, timeout time.Duration, fn func()) bool {
+	t.Helper()
+
+	if timeout == 0 {
+		fn()
+		return true
+	}
+
+	done := make(chan struct{})
+
+	var abandoned atomic.Bool
+	go func() {
+		defer close(done)
+		defer func() {
+			r := recover()
+			if r == nil || abandoned.Load() {
+				return
+			}
+
+			t.Errorf("test \"%s\" panicked in %s: %v\n%s", t.Name(), step, r, debug.Stack())
+		}()
+
+		fn()
+	}()
+
+	timer := time.NewTimer(time.Until(start.Add(timeout)))
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		abandoned.Store(true)
+		t.Errorf("test \"%s\" timed out in %s after %s (timeout %s)", t.Name(), step, time.Since(start).Round(time.Millisecond), timeout)
+		return false
+	}
+}
+
 // registerTest registers a test to the group. Do not overwrite existing tests.
 func (tg *TestGroup) registerTest(name string, testFn TestFn, options TestOpts) error {
-	if _, ok := tg.cache[name]; ok {
-		return fmt.Errorf("%w: %s", ErrTestAlreadyExists, name)
+	status := StatusTest
+	if options.Fails {
+		status = StatusFails
 	}
 
-	tg.cache[name] = struct{}{}
-	tg.registry = append(tg.registry, TestRegistryEntry{
+	if options.Synctest {
+		testFn = synctestFn(testFn)
+	}
+
+	return tg.registerEntry(TestRegistryEntry{
 		name:    name,
 		fn:      testFn,
 		options: options,
+		status:  status,
 	})
+}
+
+// registerEntry adds an entry to the registry. Do not overwrite existing tests.
+func (tg *TestGroup) registerEntry(entry TestRegistryEntry) error {
+	if _, ok := tg.cache[entry.name]; ok {
+		return fmt.Errorf("%w: %s", ErrTestAlreadyExists, entry.name)
+	}
+
+	if entry.options.Only {
+		suite.recordOnly(tg.t.Name() + "/" + entry.name)
+	}
+
+	tg.cache[entry.name] = struct{}{}
+	tg.registry = append(tg.registry, entry)
 	return nil
 }
 
@@ -144,7 +498,8 @@ func (tg *TestGroup) registerCleanupTasks() {
 			return
 		}
 
-		if !tg.complete && len(tg.registry) > 0 {
+		// a group that started may not complete if a test panics, which is reported against the test
+		if !tg.started && len(tg.registry) > 0 {
 			tg.t.Fatalf("test group \"%s\" did not run. Make sure you use the .Run() method to execute test group", tg.t.Name())
 		}
 	})
@@ -168,6 +523,20 @@ func (tg *TestGroup) sanitiseLifecycle() {
 		tg.afterEach = func() {}
 	}
 
+	if len(tg.spies) > 0 || len(tg.fakeClocks) > 0 {
+		beforeEach := tg.beforeEach
+		tg.beforeEach = func() {
+			tg.resetFakeClocks()
+			beforeEach()
+		}
+
+		afterEach := tg.afterEach
+		tg.afterEach = func() {
+			defer tg.resetFakeClocks()
+			defer tg.resetSpies()
+			afterEach()
+		}
+	}
 }
 
 // shouldSkipTests checks if the test group should be skipped based on environment tags
@@ -202,13 +571,12 @@ func filterExecutableTests(isCIEnv bool, tests []TestRegistryEntry) ([]TestRegis
 
 	for _, test := range tests {
 		if test.options.Skip {
-			filtered = append(filtered, TestRegistryEntry{
-				name: test.name,
-				fn: func(t *testing.T) {
-					t.Skip("skipping test ", test.name)
-				},
-			})
+			filtered = append(filtered, skippedEntry(test.name, "skipping test ", test.name))
+			continue
+		}
 
+		if skip, reason := shouldSkipTest(isCIEnv, test.options); skip {
+			filtered = append(filtered, skippedEntry(test.name, "skipping test: ", reason))
 			continue
 		}
 
@@ -218,6 +586,32 @@ func filterExecutableTests(isCIEnv bool, tests []TestRegistryEntry) ([]TestRegis
 	return filtered, nil
 }
 
+// shouldSkipTest evaluates the conditional skip options of a test, returning the reason for the first condition met
+func shouldSkipTest(isCIEnv bool, options TestOpts) (bool, string) {
+	if options.SkipInCI && isCIEnv {
+		return true, "running in CI environment"
+	}
+
+	for _, condition := range options.SkipConditions {
+		if skip, reason := condition(); skip {
+			return true, reason
+		}
+	}
+
+	return false, ""
+}
+
+// skippedEntry creates an entry that skips the test with the provided reason
+func skippedEntry(name string, reason ...any) TestRegistryEntry {
+	return TestRegistryEntry{
+		name: name,
+		fn: func(t *testing.T) {
+			t.Skip(reason...)
+		},
+		skipped: true,
+	}
+}
+
 // filterOnlyAllowedTests filters tests that are marked as only within a test group
 // If the framework detects that the test is running under a CI environment and the group has tests with 'Only', then it will return an error
 func filterOnlyAllowedTests(isCIEnv bool, tests []TestRegistryEntry) ([]TestRegistryEntry, error) {
@@ -235,3 +629,15 @@ func filterOnlyAllowedTests(isCIEnv bool, tests []TestRegistryEntry) ([]TestRegi
 
 	return filtered, nil
 }
+
+// String - return the status name used in reports
+func (s TestStatus) String() string {
+	switch s {
+	case StatusTodo:
+		return "todo"
+	case StatusFails:
+		return "expected failure"
+	default:
+		return "test"
+	}
+}
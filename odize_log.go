@@ -5,7 +5,7 @@ import (
 )
 
 // log without formatting
-func log(t *testing.T, args ...any) {
+func log(t testing.TB, args ...any) {
 	t.Helper()
 
 	t.Error(args...)
@@ -1,8 +1,15 @@
 package odize
 
 import (
+	"context"
 	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestUnitNoEnvVarShouldRunAll(t *testing.T) {
@@ -260,11 +267,11 @@ func TestRegisterCleanupTaskShouldNotFailIfSkipped(t *testing.T) {
 	tg.registerCleanupTasks()
 }
 
-func TestRegisterCleanupTaskShouldNotFailIfComplete(t *testing.T) {
+func TestRegisterCleanupTaskShouldNotFailIfStarted(t *testing.T) {
 	tg := TestGroup{
-		t:        t,
-		cache:    map[string]struct{}{},
-		complete: true,
+		t:       t,
+		cache:   map[string]struct{}{},
+		started: true,
 	}
 
 	err := tg.registerTest("test", func(t *testing.T) {}, TestOpts{})
@@ -347,3 +354,563 @@ func TestCITestNoOnly(t *testing.T) {
 		Run()
 	AssertNoError(t, err)
 }
+
+func TestTimeout(t *testing.T) {
+	tg := NewGroup(t, nil)
+
+	afterEachCalls := 0
+	tg.AfterEach(func() {
+		afterEachCalls++
+	})
+
+	err := tg.
+		Timeout(time.Second).
+		Test("should pass within group timeout", func(t *testing.T) {
+			AssertTrue(t, true)
+		}).
+		Test("should pass within test timeout", func(t *testing.T) {
+			AssertTrue(t, true)
+		}, Timeout(500*time.Millisecond)).
+		Run()
+
+	AssertNoError(t, err)
+	AssertEqual(t, 2, afterEachCalls)
+}
+
+func TestTimeoutContinuesGroup(t *testing.T) {
+	output := runTestProcess(t, "TestProcessTimeoutLateError")
+
+	AssertContainsString(t, output, "--- FAIL: TestProcessTimeoutLateError/times_out")
+	AssertContainsString(t, output, "timed out in test body")
+	AssertContainsString(t, output, "context cancelled before the next test")
+	AssertContainsString(t, output, "--- PASS: TestProcessTimeoutLateError/runs_next")
+	AssertContainsString(t, output, "after each calls: 2")
+	AssertContainsString(t, output, "late failure did not crash the test binary")
+	AssertFalse(t, strings.Contains(output, "panic:"))
+}
+
+func TestProcessTimeoutLateError(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	cancelled := make(chan struct{})
+	late := make(chan struct{})
+	afterEachCalls := 0
+
+	tg := NewGroup(t, nil)
+	tg.AfterEach(func() {
+		afterEachCalls++
+	})
+
+	_ = tg.
+		Timeout(20*time.Millisecond).
+		Test("times out", func(t *testing.T) {
+			defer close(late)
+
+			<-t.Context().Done()
+			close(cancelled)
+
+			t.Error("late failure")
+		}).
+		Test("runs next", func(t *testing.T) {
+			select {
+			case <-cancelled:
+				t.Log("context cancelled before the next test")
+			case <-time.After(time.Second):
+				t.Error("context was not cancelled")
+			}
+		}).
+		Run()
+
+	<-late
+	t.Logf("after each calls: %d", afterEachCalls)
+	t.Log("late failure did not crash the test binary")
+}
+
+func TestRunStep(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should fail step that exceeds timeout", func(t *testing.T) {
+			c := &Collect{TB: t}
+			release := make(chan struct{})
+			defer close(release)
+
+			ok := runStep(c, "BeforeEach", time.Now(), 10*time.Millisecond, func() {
+				<-release
+			})
+
+			AssertFalse(t, ok)
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), "timed out in BeforeEach"))
+			AssertTrue(t, strings.Contains(c.String(), "(timeout 10ms)"))
+		}).
+		Test("should wait for step without timeout", func(t *testing.T) {
+			called := false
+			ok := runStep(t, "test body", time.Now(), 0, func() {
+				called = true
+			})
+
+			AssertTrue(t, ok)
+			AssertTrue(t, called)
+		}).
+		Test("should continue after step exits early", func(t *testing.T) {
+			c := &Collect{TB: t}
+			ok := runStep(c, "test body", time.Now(), time.Second, func() {
+				c.FailNow()
+			})
+
+			AssertTrue(t, ok)
+			AssertTrue(t, c.Failed())
+		}).
+		Test("should report a panic in the step", func(t *testing.T) {
+			c := &Collect{TB: t}
+			ok := runStep(c, "AfterEach", time.Now(), time.Second, func() {
+				panic("closed twice")
+			})
+
+			AssertTrue(t, ok)
+			AssertTrue(t, c.Failed())
+			AssertContainsString(t, c.String(), "panicked in AfterEach: closed twice")
+			AssertContainsString(t, c.String(), "runtime/debug.Stack")
+		}).
+		Test("should share the deadline across steps", func(t *testing.T) {
+			c := &Collect{TB: t}
+			ok := runStep(c, "AfterEach", time.Now().Add(-time.Second), time.Second, func() {
+				time.Sleep(time.Second)
+			})
+
+			AssertFalse(t, ok)
+			AssertContainsString(t, c.String(), "timed out in AfterEach after 1")
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestPanic(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should report a panic against the test without a timeout", func(t *testing.T) {
+			output := runTestProcess(t, "TestProcessPanic")
+
+			AssertContainsString(t, output, "--- FAIL: TestProcessPanic/panics")
+			AssertContainsString(t, output, "panic: assignment to entry in nil map")
+			AssertContainsString(t, output, "after each ran")
+			AssertContainsString(t, output, "cleanup ran")
+		}).
+		Test("should report a panic and continue the group with a timeout", func(t *testing.T) {
+			output := runTestProcess(t, "TestProcessPanicTimeout")
+
+			AssertContainsString(t, output, "--- FAIL: TestProcessPanicTimeout/panics")
+			AssertContainsString(t, output, "panicked in test body: assignment to entry in nil map")
+			AssertContainsString(t, output, "cleanup ran")
+			AssertContainsString(t, output, "--- PASS: TestProcessPanicTimeout/runs_next")
+		}).
+		Test("should time out when steps together exceed the timeout", func(t *testing.T) {
+			output := runTestProcess(t, "TestProcessTimeoutDeadline")
+
+			AssertContainsString(t, output, "--- FAIL: TestProcessTimeoutDeadline/slow_steps")
+			AssertContainsString(t, output, "timed out in test body")
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestProcessPanic(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	tg := NewGroup(t, nil)
+	tg.AfterEach(func() {
+		t.Log("after each ran")
+	})
+
+	_ = tg.
+		Test("panics", func(t *testing.T) {
+			t.Cleanup(func() {
+				t.Log("cleanup ran")
+			})
+
+			var counts map[string]int
+			counts["calls"]++
+		}).
+		Run()
+}
+
+func TestProcessPanicTimeout(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	_ = NewGroup(t, nil).
+		Timeout(time.Second).
+		Test("panics", func(t *testing.T) {
+			t.Cleanup(func() {
+				t.Log("cleanup ran")
+			})
+
+			var counts map[string]int
+			counts["calls"]++
+		}).
+		Test("runs next", func(t *testing.T) {}).
+		Run()
+}
+
+func TestProcessTimeoutDeadline(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	tg := NewGroup(t, nil)
+	tg.BeforeEach(func() {
+		time.Sleep(60 * time.Millisecond)
+	})
+
+	_ = tg.
+		Timeout(100*time.Millisecond).
+		Test("slow steps", func(t *testing.T) {
+			time.Sleep(60 * time.Millisecond)
+		}).
+		Run()
+}
+
+func TestRetry(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should report flaky test that passes after retry", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			beforeEachCalls := newProcessCounter(t, "RETRY_BEFORE_EACH")
+			afterEachCalls := newProcessCounter(t, "RETRY_AFTER_EACH")
+			tg.BeforeEach(func() {
+				beforeEachCalls.Inc(t)
+			})
+			tg.AfterEach(func() {
+				afterEachCalls.Inc(t)
+			})
+
+			bodyCalls := newProcessCounter(t, "RETRY_BODY")
+			err := tg.
+				Test("passes on third attempt", func(t *testing.T) {
+					AssertEqual(t, 3, bodyCalls.Inc(t))
+				}, Retry(2)).
+				Run()
+
+			AssertTrue(t, errors.Is(err, ErrTestFlaky))
+			AssertTrue(t, strings.Contains(err.Error(), "\"passes on third attempt\" passed on attempt 3 of 3"))
+			AssertEqual(t, 3, bodyCalls.Value(t))
+			AssertEqual(t, 3, beforeEachCalls.Value(t))
+			AssertEqual(t, 3, afterEachCalls.Value(t))
+		}).
+		Test("should not report stable test as flaky", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			bodyCalls := newProcessCounter(t, "STABLE_BODY")
+			err := tg.
+				Retry(3).
+				Test("passes first time", func(t *testing.T) {
+					bodyCalls.Inc(t)
+				}).
+				Run()
+
+			AssertNoError(t, err)
+			AssertEqual(t, 1, bodyCalls.Value(t))
+		}).
+		Test("should use group retry default", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			bodyCalls := newProcessCounter(t, "DEFAULT_BODY")
+			err := tg.
+				Retry(1).
+				Test("passes on second attempt", func(t *testing.T) {
+					if bodyCalls.Inc(t) == 1 {
+						t.FailNow()
+					}
+				}).
+				Run()
+
+			AssertTrue(t, errors.Is(err, ErrTestFlaky))
+			AssertEqual(t, 2, bodyCalls.Value(t))
+		}).
+		Test("should give each attempt its own context, cleanups, subtests and environment", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			bodyCalls := newProcessCounter(t, "ATTEMPT_BODY")
+			cleanups := newProcessCounter(t, "ATTEMPT_CLEANUP")
+			subtests := newProcessCounter(t, "ATTEMPT_SUBTEST")
+			err := tg.
+				Test("passes on second attempt", func(t *testing.T) {
+					AssertNoError(t, context.Cause(t.Context()))
+					AssertEqual(t, "", os.Getenv("ODIZE_TEST_ATTEMPT_ENV"))
+
+					t.Setenv("ODIZE_TEST_ATTEMPT_ENV", "set")
+					t.Cleanup(func() {
+						cleanups.Inc(t)
+					})
+					t.Run("subtest", func(t *testing.T) {
+						subtests.Inc(t)
+					})
+
+					AssertEqual(t, 2, bodyCalls.Inc(t))
+				}, Retry(1)).
+				Run()
+
+			AssertTrue(t, errors.Is(err, ErrTestFlaky))
+			AssertEqual(t, 2, cleanups.Value(t))
+			AssertEqual(t, 2, subtests.Value(t))
+			AssertEqual(t, "", os.Getenv("ODIZE_TEST_ATTEMPT_ENV"))
+		}).
+		Test("should replay the output of failed attempts", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			bodyCalls := newProcessCounter(t, "REPLAY_BODY")
+			err := tg.
+				Test("passes on second attempt", func(t *testing.T) {
+					if bodyCalls.Inc(t) == 1 {
+						t.Error("connection refused")
+					}
+				}, Retry(1)).
+				Run()
+
+			AssertTrue(t, errors.Is(err, ErrTestFlaky))
+
+			flaky := suite.flakyTests[len(suite.flakyTests)-1]
+			AssertEqual(t, t.Name()+"/passes_on_second_attempt", flaky.name)
+			AssertEqual(t, 1, len(flaky.failures))
+			AssertTrue(t, strings.HasPrefix(flaky.failures[0], "attempt 1 of 2 failed:\n"))
+			AssertTrue(t, strings.Contains(flaky.failures[0], "connection refused"))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+// testProcessEnv is set when a test is run by runTestProcess
+const testProcessEnv = "ODIZE_TEST_PROCESS"
+
+// runTestProcess runs the top level test in a child process of the test binary, returning its verbose output.
+// Used for tests that are expected to fail, or crash, the test binary.
+func runTestProcess(t *testing.T, name string) string {
+	t.Helper()
+
+	return runTestProcessIn(t, t.TempDir(), name)
+}
+
+// runTestProcessIn runs the top level test in a child process within dir, where the failures of the run are recorded
+func runTestProcessIn(t *testing.T, dir string, name string) string {
+	t.Helper()
+
+	executable, err := os.Executable()
+	AssertNoError(t, err)
+
+	cmd := exec.CommandContext(t.Context(), executable, "-test.run=^"+name+"$", "-test.count=1", "-test.v=true")
+	cmd.Env = append(os.Environ(), testProcessEnv+"=true")
+	cmd.Dir = dir
+
+	output, _ := cmd.CombinedOutput()
+
+	return string(output)
+}
+
+// skipUnlessTestProcess skips tests that should only run within runTestProcess
+func skipUnlessTestProcess(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv(testProcessEnv) == "" {
+		t.Skip("run by runTestProcess")
+	}
+}
+
+// processCounter - Counter stored in a file, shared with the child processes that run isolated attempts
+type processCounter struct {
+	path string
+}
+
+// newProcessCounter creates a counter, or reuses the counter created by the parent process when running an isolated attempt
+func newProcessCounter(t *testing.T, name string) processCounter {
+	t.Helper()
+
+	key := "ODIZE_TEST_COUNTER_" + name
+	if path := os.Getenv(key); path != "" {
+		return processCounter{path: path}
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	t.Setenv(key, path)
+
+	return processCounter{path: path}
+}
+
+// Inc increments the counter, returning the new value
+func (c processCounter) Inc(t testing.TB) int {
+	t.Helper()
+
+	value := c.Value(t) + 1
+	AssertNoError(t, os.WriteFile(c.path, []byte(strconv.Itoa(value)), 0o600))
+
+	return value
+}
+
+// Value returns the current value of the counter
+func (c processCounter) Value(t testing.TB) int {
+	t.Helper()
+
+	content, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0
+	}
+	AssertNoError(t, err)
+
+	value, err := strconv.Atoi(string(content))
+	AssertNoError(t, err)
+
+	return value
+}
+
+func TestTodo(t *testing.T) {
+	tg := NewGroup(t, nil)
+
+	err := tg.
+		Todo("should support refunds").
+		Test("should pass", func(t *testing.T) {
+			AssertTrue(t, true)
+		}).
+		Run()
+
+	AssertNoError(t, err)
+	AssertEqual(t, StatusTodo, tg.registry[0].status)
+	AssertEqual(t, StatusTest, tg.registry[1].status)
+}
+
+func TestTodoShouldNotAllowDuplicates(t *testing.T) {
+	tg := NewGroup(t, nil)
+
+	err := tg.
+		Todo("should support refunds").
+		Todo("should support refunds").
+		Run()
+
+	AssertTrue(t, errors.Is(err, ErrTestAlreadyExists))
+}
+
+func TestOptionFails(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when body fails", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			afterEachCalls := newProcessCounter(t, "FAILS_AFTER_EACH")
+			tg.AfterEach(func() {
+				afterEachCalls.Inc(t)
+			})
+
+			err := tg.
+				Test("known bug", func(t *testing.T) {
+					AssertEqual(t, 1, 2)
+				}, Fails()).
+				Run()
+
+			AssertNoError(t, err)
+			AssertEqual(t, StatusFails, tg.registry[0].status)
+			AssertEqual(t, 1, afterEachCalls.Value(t))
+		}).
+		Test("should pass when body panics", func(t *testing.T) {
+			err := NewGroup(t, nil).
+				Test("known bug", func(t *testing.T) {
+					var counts map[string]int
+					counts["calls"]++
+				}, Fails()).
+				Test("known bug with timeout", func(t *testing.T) {
+					var counts map[string]int
+					counts["calls"]++
+				}, Fails(), Timeout(time.Second)).
+				Run()
+
+			AssertNoError(t, err)
+		}).
+		Test("should fail when body unexpectedly passes", func(t *testing.T) {
+			output := runTestProcess(t, "TestProcessFailsUnexpectedPass")
+
+			AssertContainsString(t, output, "--- FAIL: TestProcessFailsUnexpectedPass/fixed_bug")
+			AssertContainsString(t, output, "is marked with Fails() but unexpectedly passed")
+		}).
+		Test("should fail when body times out", func(t *testing.T) {
+			output := runTestProcess(t, "TestProcessFailsTimeout")
+
+			AssertContainsString(t, output, "--- FAIL: TestProcessFailsTimeout/hangs")
+			AssertContainsString(t, output, "is marked with Fails() but timed out")
+			AssertContainsString(t, output, "timed out in test body")
+		}).
+		Test("should support context, cleanups and subtests", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+
+			cleanups := newProcessCounter(t, "FAILS_CLEANUP")
+			err := tg.
+				Test("known bug", func(t *testing.T) {
+					AssertNoError(t, context.Cause(t.Context()))
+					t.Cleanup(func() {
+						cleanups.Inc(t)
+					})
+					t.Run("subtest", func(t *testing.T) {
+						AssertEqual(t, 1, 2)
+					})
+				}, Fails()).
+				Run()
+
+			AssertNoError(t, err)
+			AssertEqual(t, 1, cleanups.Value(t))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestRetryParallel(t *testing.T) {
+	output := runTestProcess(t, "TestProcessRetryParallel")
+
+	AssertContainsString(t, output, "flaky: passed on attempt 2 of 2")
+	AssertContainsString(t, output, "run returned before the parallel test completed")
+	AssertContainsString(t, output, `test is flaky: "passes on second attempt" passed on attempt 2 of 2`)
+	AssertContainsString(t, output, "--- FAIL: TestProcessRetryParallel ")
+}
+
+func TestProcessRetryParallel(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	bodyCalls := newProcessCounter(t, "PARALLEL_BODY")
+	err := NewGroup(t, nil).
+		Test("passes on second attempt", func(t *testing.T) {
+			t.Parallel()
+
+			if bodyCalls.Inc(t) == 1 {
+				t.FailNow()
+			}
+		}, Retry(1)).
+		Run()
+
+	AssertNoError(t, err)
+	t.Log("run returned before the parallel test completed")
+}
+
+func TestProcessFailsUnexpectedPass(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	_ = NewGroup(t, nil).
+		Test("fixed bug", func(t *testing.T) {}, Fails()).
+		Run()
+}
+
+func TestProcessFailsTimeout(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	_ = NewGroup(t, nil).
+		Test("hangs", func(t *testing.T) {
+			<-t.Context().Done()
+		}, Fails(), Timeout(10*time.Millisecond)).
+		Run()
+}
+
+func TestStatusString(t *testing.T) {
+	AssertEqual(t, "test", StatusTest.String())
+	AssertEqual(t, "todo", StatusTodo.String())
+	AssertEqual(t, "expected failure", StatusFails.String())
+}
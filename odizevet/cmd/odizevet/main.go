@@ -0,0 +1,13 @@
+// Command odizevet reports misuse of odize test groups. Run with go vet:
+//
+//	go vet -vettool=$(which odizevet) ./...
+package main
+
+import (
+	"github.com/code-gorilla-au/odize/odizevet"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(odizevet.Analyzer)
+}
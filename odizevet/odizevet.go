@@ -0,0 +1,323 @@
+// Package odizevet provides a static analyser that reports common misuse of odize test groups.
+//
+// Run with go vet:
+//
+//	go install github.com/code-gorilla-au/odize/odizevet/cmd/odizevet@latest
+//	go vet -vettool=$(which odizevet) ./...
+package odizevet
+
+import (
+	"errors"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const odizePath = "github.com/code-gorilla-au/odize"
+
+var (
+	ErrInspectorMissing = errors.New("inspector result missing")
+)
+
+// Analyzer - Reports test groups that are never run, unchecked errors from Run, the Only option,
+// duplicate test names within a group, and hooks registered after Run.
+var Analyzer = &analysis.Analyzer{
+	Name:     "odizevet",
+	Doc:      "report misuse of odize test groups",
+	URL:      "https://pkg.go.dev/github.com/code-gorilla-au/odize/odizevet",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// hooks are the lifecycle methods that have no effect once Run has been called
+var hooks = []string{"BeforeAll", "BeforeEach", "AfterEach", "AfterAll"}
+
+// groupUsage - calls made on a single test group
+type groupUsage struct {
+	// position of the NewGroup call, zero if the group was created elsewhere
+	created token.Pos
+	escaped bool
+	runs    []token.Pos
+	hooks   []namedPos
+	tests   []namedPos
+}
+
+type namedPos struct {
+	name string
+	pos  token.Pos
+}
+
+// groupKey - either the types.Object of a variable holding a group, or the NewGroup call of an unassigned group
+type groupKey = any
+
+type state struct {
+	pass    *analysis.Pass
+	groups  map[groupKey]*groupUsage
+	handled map[*ast.Ident]bool
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	in, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok {
+		return nil, ErrInspectorMissing
+	}
+
+	s := &state{
+		pass:    pass,
+		groups:  map[groupKey]*groupUsage{},
+		handled: map[*ast.Ident]bool{},
+	}
+
+	for cur := range in.Root().Preorder((*ast.CallExpr)(nil)) {
+		s.visitCall(cur)
+	}
+
+	s.markEscaped()
+	s.report()
+
+	return nil, nil
+}
+
+// visitCall records calls to odize functions and test group methods
+func (s *state) visitCall(cur inspector.Cursor) {
+	call, ok := cur.Node().(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	fn, ok := typeutil.Callee(s.pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != odizePath {
+		return
+	}
+
+	if isGroupMethod(fn) {
+		s.visitMethod(cur, call, fn.Name())
+		return
+	}
+
+	switch fn.Name() {
+	case "NewGroup":
+		s.visitNewGroup(cur, call)
+	case "Only":
+		s.pass.Reportf(call.Pos(), "test option Only() should not be committed, it is not allowed in CI")
+	case "Fixture":
+		// declaring a fixture does not run or leak the group
+		if len(call.Args) > 0 {
+			if id, ok := ast.Unparen(call.Args[0]).(*ast.Ident); ok {
+				s.handled[id] = true
+			}
+		}
+	}
+}
+
+// visitNewGroup tracks the group created by NewGroup, when assigned to a variable or used in a method chain
+func (s *state) visitNewGroup(cur inspector.Cursor, call *ast.CallExpr) {
+	switch parent := cur.Parent().Node().(type) {
+	case *ast.AssignStmt:
+		if index := slices.Index(parent.Rhs, ast.Expr(call)); index >= 0 && len(parent.Lhs) == len(parent.Rhs) {
+			s.trackAssigned(parent.Lhs[index], call)
+		}
+	case *ast.ValueSpec:
+		if index := slices.Index(parent.Values, ast.Expr(call)); index >= 0 && len(parent.Names) == len(parent.Values) {
+			s.trackAssigned(parent.Names[index], call)
+		}
+	case *ast.SelectorExpr:
+		s.group(call).created = call.Pos()
+	}
+}
+
+// trackAssigned tracks a group assigned to a variable
+func (s *state) trackAssigned(lhs ast.Expr, call *ast.CallExpr) {
+	id, ok := lhs.(*ast.Ident)
+	if !ok || id.Name == "_" {
+		return
+	}
+
+	obj := s.pass.TypesInfo.ObjectOf(id)
+	if obj == nil {
+		return
+	}
+
+	s.handled[id] = true
+	s.group(obj).created = call.Pos()
+}
+
+// visitMethod records a method call on a test group
+func (s *state) visitMethod(cur inspector.Cursor, call *ast.CallExpr, name string) {
+	key := s.rootOf(call)
+	if key == nil {
+		return
+	}
+
+	g := s.group(key)
+
+	switch {
+	case name == "Run":
+		g.runs = append(g.runs, call.Pos())
+		s.checkRunError(cur, call)
+	case slices.Contains(hooks, name):
+		g.hooks = append(g.hooks, namedPos{name: name, pos: call.Pos()})
+	case name == "Test" || name == "SyncTest" || name == "Todo":
+		if testName, ok := literalString(call); ok {
+			g.tests = append(g.tests, namedPos{name: testName, pos: call.Args[0].Pos()})
+		}
+	}
+}
+
+// checkRunError reports calls to Run where the returned error is discarded
+func (s *state) checkRunError(cur inspector.Cursor, call *ast.CallExpr) {
+	pos := call.Pos()
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		// report on the Run selector, rather than the start of a multi line method chain
+		pos = sel.Sel.Pos()
+	}
+
+	switch parent := cur.Parent().Node().(type) {
+	case *ast.ExprStmt:
+		s.pass.Reportf(pos, "error returned by Run is not checked")
+	case *ast.AssignStmt:
+		index := slices.Index(parent.Rhs, ast.Expr(call))
+		if index < 0 || index >= len(parent.Lhs) {
+			return
+		}
+
+		if id, ok := parent.Lhs[index].(*ast.Ident); ok && id.Name == "_" {
+			s.pass.Reportf(pos, "error returned by Run is not checked")
+		}
+	}
+}
+
+// rootOf walks a method chain e.g. group.Test(...).Test(...).Run() back to the group it was called on
+func (s *state) rootOf(call *ast.CallExpr) groupKey {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	receiver := ast.Unparen(sel.X)
+	for {
+		switch expr := receiver.(type) {
+		case *ast.Ident:
+			s.handled[expr] = true
+			return s.pass.TypesInfo.Uses[expr]
+		case *ast.CallExpr:
+			fn, ok := typeutil.Callee(s.pass.TypesInfo, expr).(*types.Func)
+			if !ok || fn.Pkg() == nil || fn.Pkg().Path() != odizePath {
+				return nil
+			}
+
+			if fn.Name() == "NewGroup" {
+				return expr
+			}
+
+			inner, ok := expr.Fun.(*ast.SelectorExpr)
+			if !ok || !isGroupMethod(fn) {
+				return nil
+			}
+
+			receiver = ast.Unparen(inner.X)
+		default:
+			return nil
+		}
+	}
+}
+
+// markEscaped marks groups whose variable is used outside of a method chain, e.g. passed to a helper that may run it
+func (s *state) markEscaped() {
+	for id, obj := range s.pass.TypesInfo.Uses {
+		if g, ok := s.groups[obj]; ok && !s.handled[id] {
+			g.escaped = true
+		}
+	}
+}
+
+// report reports misuse found for each group
+func (s *state) report() {
+	for _, g := range s.groups {
+		if g.created.IsValid() && !g.escaped && len(g.runs) == 0 {
+			s.pass.Reportf(g.created, "test group is never run, call .Run() to execute the tests")
+		}
+
+		s.reportDuplicateTests(g)
+		s.reportHooksAfterRun(g)
+	}
+}
+
+// reportDuplicateTests reports test names registered more than once within a group
+func (s *state) reportDuplicateTests(g *groupUsage) {
+	slices.SortFunc(g.tests, func(a, b namedPos) int {
+		return int(a.pos - b.pos)
+	})
+
+	seen := map[string]bool{}
+	for _, test := range g.tests {
+		if seen[test.name] {
+			s.pass.Reportf(test.pos, "duplicate test name %q in group, the test will not be registered", test.name)
+		}
+
+		seen[test.name] = true
+	}
+}
+
+// reportHooksAfterRun reports hooks registered after the group has been run
+func (s *state) reportHooksAfterRun(g *groupUsage) {
+	if len(g.runs) == 0 {
+		return
+	}
+
+	firstRun := slices.Min(g.runs)
+	for _, hook := range g.hooks {
+		if hook.pos > firstRun {
+			s.pass.Reportf(hook.pos, "%s registered after Run, the hook will never be called", hook.name)
+		}
+	}
+}
+
+// group returns the usage for a group, creating it on first use
+func (s *state) group(key groupKey) *groupUsage {
+	g, ok := s.groups[key]
+	if !ok {
+		g = &groupUsage{}
+		s.groups[key] = g
+	}
+
+	return g
+}
+
+// isGroupMethod checks if fn is a method on odize.TestGroup
+func isGroupMethod(fn *types.Func) bool {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return false
+	}
+
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+
+	named, ok := ptr.Elem().(*types.Named)
+	return ok && named.Obj().Name() == "TestGroup"
+}
+
+// literalString returns the first argument of a call if it is a string literal
+func literalString(call *ast.CallExpr) (string, bool) {
+	if len(call.Args) == 0 {
+		return "", false
+	}
+
+	lit, ok := ast.Unparen(call.Args[0]).(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
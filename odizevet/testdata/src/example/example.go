@@ -0,0 +1,73 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func runGroup(tg *odize.TestGroup) error {
+	return tg.Run()
+}
+
+func GroupNeverRun(t *testing.T) {
+	group := odize.NewGroup(t, nil) // want `test group is never run, call .Run\(\) to execute the tests`
+
+	group.Test("should pass", func(t *testing.T) {})
+}
+
+func GroupRunIgnoresError(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	group.
+		Test("should pass", func(t *testing.T) {}).
+		Run() // want `error returned by Run is not checked`
+
+	_ = odize.NewGroup(t, nil).Run() // want `error returned by Run is not checked`
+}
+
+func GroupWithOnly(t *testing.T) error {
+	return odize.NewGroup(t, nil).
+		Test("should pass", func(t *testing.T) {}, odize.Only()). // want `test option Only\(\) should not be committed, it is not allowed in CI`
+		Run()
+}
+
+func GroupWithDuplicateNames(t *testing.T) error {
+	group := odize.NewGroup(t, nil)
+
+	return group.
+		Test("should pass", func(t *testing.T) {}).
+		Todo("should pass").                            // want `duplicate test name "should pass" in group, the test will not be registered`
+		SyncTest("should pass", func(t *testing.T) {}). // want `duplicate test name "should pass" in group, the test will not be registered`
+		Run()
+}
+
+func GroupWithHookAfterRun(t *testing.T) error {
+	group := odize.NewGroup(t, nil)
+
+	group.BeforeEach(func() {})
+
+	err := group.Test("should pass", func(t *testing.T) {}).Run()
+
+	group.AfterEach(func() {}) // want `AfterEach registered after Run, the hook will never be called`
+
+	return err
+}
+
+func GroupPassedToHelper(t *testing.T) error {
+	group := odize.NewGroup(t, nil)
+
+	group.Test("should pass", func(t *testing.T) {})
+
+	return runGroup(group)
+}
+
+func GroupWithFixture(t *testing.T) error {
+	group := odize.NewGroup(t, nil)
+
+	_ = odize.Fixture(group, func(t testing.TB) (string, func()) {
+		return "", nil
+	})
+
+	return group.Test("should pass", func(t *testing.T) {}, odize.Skip()).Run()
+}
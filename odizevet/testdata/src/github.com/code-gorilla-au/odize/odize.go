@@ -0,0 +1,36 @@
+// Package odize is a minimal stub of the odize API used by the analyser tests.
+package odize
+
+import "testing"
+
+type TestGroup struct{}
+
+type TestFuncOpts = func()
+
+func NewGroup(t *testing.T, tags *[]string) *TestGroup { return &TestGroup{} }
+
+func Only() TestFuncOpts { return func() {} }
+
+func Skip() TestFuncOpts { return func() {} }
+
+func Fixture[T any](tg *TestGroup, setup func(t testing.TB) (T, func())) *T { return nil }
+
+func (tg *TestGroup) Test(name string, fn func(t *testing.T), options ...TestFuncOpts) *TestGroup {
+	return tg
+}
+
+func (tg *TestGroup) SyncTest(name string, fn func(t *testing.T), options ...TestFuncOpts) *TestGroup {
+	return tg
+}
+
+func (tg *TestGroup) Todo(name string) *TestGroup { return tg }
+
+func (tg *TestGroup) BeforeEach(fn func()) {}
+
+func (tg *TestGroup) BeforeAll(fn func()) {}
+
+func (tg *TestGroup) AfterEach(fn func()) {}
+
+func (tg *TestGroup) AfterAll(fn func()) {}
+
+func (tg *TestGroup) Run() error { return nil }
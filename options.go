@@ -1,5 +1,15 @@
 package odize
 
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
 // Skip - Skip this test
 func Skip() TestFuncOpts {
 	return func(to *TestOpts) {
@@ -7,6 +17,55 @@ func Skip() TestFuncOpts {
 	}
 }
 
+// SkipIf - Skip this test if the condition returns true, using the returned reason as the skip message.
+//
+// Example:
+//
+//	SkipIf(func() (bool, string) {
+//		return runtime.GOOS == "windows", "not supported on windows"
+//	})
+func SkipIf(condition SkipCondition) TestFuncOpts {
+	return func(to *TestOpts) {
+		to.SkipConditions = append(to.SkipConditions, condition)
+	}
+}
+
+// SkipInShort - Skip this test when running with the -short flag
+func SkipInShort() TestFuncOpts {
+	return SkipIf(func() (bool, string) {
+		return testing.Short(), "running in short mode"
+	})
+}
+
+// SkipOnOS - Skip this test when running on any of the operating systems, e.g. "windows"
+func SkipOnOS(goos ...string) TestFuncOpts {
+	return SkipIf(func() (bool, string) {
+		return slices.Contains(goos, runtime.GOOS), fmt.Sprintf("not supported on %s", runtime.GOOS)
+	})
+}
+
+// SkipInCI - Skip this test when running within a CI environment
+func SkipInCI() TestFuncOpts {
+	return func(to *TestOpts) {
+		to.SkipInCI = true
+	}
+}
+
+// RequireEnv - Skip this test if the environment variable is not set
+func RequireEnv(name string) TestFuncOpts {
+	return SkipIf(func() (bool, string) {
+		return os.Getenv(name) == "", fmt.Sprintf("requires environment variable %s", name)
+	})
+}
+
+// RequireBinary - Skip this test if the binary is not found in PATH
+func RequireBinary(name string) TestFuncOpts {
+	return SkipIf(func() (bool, string) {
+		_, err := exec.LookPath(name)
+		return err != nil, fmt.Sprintf("requires binary %s in PATH", name)
+	})
+}
+
 // Only - Only run this test.
 // If multiple tests are marked as only,
 // the group will run only the tests marked as only
@@ -15,3 +74,34 @@ func Only() TestFuncOpts {
 		to.Only = true
 	}
 }
+
+// Timeout - Fail this test if it, or its BeforeEach and AfterEach hooks, take longer than d.
+// Overrides the group timeout. The test's context is cancelled once the test has timed out.
+func Timeout(d time.Duration) TestFuncOpts {
+	return func(to *TestOpts) {
+		to.Timeout = d
+	}
+}
+
+// Retry - Retry this test up to n times if it fails, running BeforeEach and AfterEach around each attempt.
+// Overrides the group retry count. Tests that only pass after a retry are reported as flaky.
+func Retry(n int) TestFuncOpts {
+	return func(to *TestOpts) {
+		to.Retry = n
+	}
+}
+
+// Fails - Mark this test as a known bug that is expected to fail.
+// The test passes when the body fails, and fails if the body unexpectedly passes.
+func Fails() TestFuncOpts {
+	return func(to *TestOpts) {
+		to.Fails = true
+	}
+}
+
+// Synctest - Run this test inside a synctest bubble with fake time. See TestGroup.SyncTest.
+func Synctest() TestFuncOpts {
+	return func(to *TestOpts) {
+		to.Synctest = true
+	}
+}
@@ -0,0 +1,88 @@
+package odize
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestConditionalSkipOptions(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should skip when condition is met", func(t *testing.T) {
+			opts := TestOpts{}
+			SkipIf(func() (bool, string) { return true, "feature disabled" })(&opts)
+
+			skip, reason := shouldSkipTest(false, opts)
+			AssertTrue(t, skip)
+			AssertEqual(t, "feature disabled", reason)
+		}).
+		Test("should not skip when condition is not met", func(t *testing.T) {
+			opts := TestOpts{}
+			SkipIf(func() (bool, string) { return false, "feature disabled" })(&opts)
+
+			skip, _ := shouldSkipTest(false, opts)
+			AssertFalse(t, skip)
+		}).
+		Test("should skip in CI", func(t *testing.T) {
+			opts := TestOpts{}
+			SkipInCI()(&opts)
+
+			skip, reason := shouldSkipTest(true, opts)
+			AssertTrue(t, skip)
+			AssertEqual(t, "running in CI environment", reason)
+
+			skip, _ = shouldSkipTest(false, opts)
+			AssertFalse(t, skip)
+		}).
+		Test("should skip on current OS", func(t *testing.T) {
+			opts := TestOpts{}
+			SkipOnOS(runtime.GOOS)(&opts)
+
+			skip, reason := shouldSkipTest(false, opts)
+			AssertTrue(t, skip)
+			AssertEqual(t, "not supported on "+runtime.GOOS, reason)
+		}).
+		Test("should skip when env var is missing", func(t *testing.T) {
+			t.Setenv("ODIZE_TEST_REQUIRED", "")
+			opts := TestOpts{}
+			RequireEnv("ODIZE_TEST_REQUIRED")(&opts)
+
+			skip, reason := shouldSkipTest(false, opts)
+			AssertTrue(t, skip)
+			AssertEqual(t, "requires environment variable ODIZE_TEST_REQUIRED", reason)
+		}).
+		Test("should not skip when env var is set", func(t *testing.T) {
+			t.Setenv("ODIZE_TEST_REQUIRED", "set")
+			opts := TestOpts{}
+			RequireEnv("ODIZE_TEST_REQUIRED")(&opts)
+
+			skip, _ := shouldSkipTest(false, opts)
+			AssertFalse(t, skip)
+		}).
+		Test("should skip when binary is missing", func(t *testing.T) {
+			opts := TestOpts{}
+			RequireBinary("odize-binary-that-does-not-exist")(&opts)
+
+			skip, reason := shouldSkipTest(false, opts)
+			AssertTrue(t, skip)
+			AssertEqual(t, "requires binary odize-binary-that-does-not-exist in PATH", reason)
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestSkipIfShouldNotRunTest(t *testing.T) {
+	tg := NewGroup(t, nil)
+
+	executed := false
+	err := tg.
+		Test("should not execute", func(t *testing.T) {
+			executed = true
+		}, SkipIf(func() (bool, string) { return true, "disabled" })).
+		Run()
+
+	AssertNoError(t, err)
+	AssertFalse(t, executed)
+}
@@ -0,0 +1,94 @@
+package odize
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+)
+
+const noPanic = "no panic"
+
+// AssertPanics checks fn panics
+//
+// Example:
+//
+//	AssertPanics(t, func() { MustParse("invalid") })
+func AssertPanics(t testing.TB, fn func()) {
+	t.Helper()
+
+	if panicked, _, _ := recoverPanic(fn); !panicked {
+		log(t, decorateDiff("panic", noPanic))
+	}
+}
+
+// AssertNotPanics checks fn does not panic, reporting the recovered value and stack if it does
+//
+// Example:
+//
+//	AssertNotPanics(t, func() { MustParse("valid") })
+func AssertNotPanics(t testing.TB, fn func()) {
+	t.Helper()
+
+	if panicked, value, stack := recoverPanic(fn); panicked {
+		log(t, decorateDiff(noPanic, formatPanic(value, stack)))
+	}
+}
+
+// AssertPanicsWithValue checks fn panics with a value equal to expected
+//
+// Example:
+//
+//	AssertPanicsWithValue(t, "invalid input", func() { MustParse("invalid") })
+func AssertPanicsWithValue(t testing.TB, expected any, fn func()) {
+	t.Helper()
+
+	panicked, value, stack := recoverPanic(fn)
+	if !panicked {
+		log(t, decorateDiff(fmt.Sprintf("panic: %v", expected), noPanic))
+		return
+	}
+
+	if !isEqual(expected, value) {
+		log(t, decorateDiff(fmt.Sprintf("panic: %v", expected), formatPanic(value, stack)))
+	}
+}
+
+// AssertPanicsWithError checks fn panics with an error that matches errTarget, using errors.Is
+//
+// Example:
+//
+//	AssertPanicsWithError(t, ErrInvalidInput, func() { MustParse("invalid") })
+func AssertPanicsWithError(t testing.TB, errTarget error, fn func()) {
+	t.Helper()
+
+	panicked, value, stack := recoverPanic(fn)
+	if !panicked {
+		log(t, decorateDiff(fmt.Sprintf("panic: %v", errTarget), noPanic))
+		return
+	}
+
+	err, ok := value.(error)
+	if !ok || !errors.Is(err, errTarget) {
+		log(t, decorateDiff(fmt.Sprintf("panic: %v", errTarget), formatPanic(value, stack)))
+	}
+}
+
+// recoverPanic runs fn, returning whether it panicked along with the recovered value and the stack of the panic
+func recoverPanic(fn func()) (panicked bool, value any, stack string) {
+	defer func() {
+		if value = recover(); value != nil {
+			panicked = true
+			stack = string(debug.Stack())
+		}
+	}()
+
+	fn()
+
+	return false, nil, ""
+}
+
+// formatPanic formats the recovered value with its stack
+func formatPanic(value any, stack string) string {
+	return fmt.Sprintf("panic: %v\n\n%s", value, stack)
+}
@@ -0,0 +1,77 @@
+package odize
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var errPanicTest = errors.New("panic test")
+
+func TestPanicAssertions(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("AssertPanics should pass when fn panics", func(t *testing.T) {
+			AssertPanics(t, func() { panic("boom") })
+		}).
+		Test("AssertPanics should pass when fn panics with nil", func(t *testing.T) {
+			AssertPanics(t, func() { panic(nil) })
+		}).
+		Test("AssertPanics should fail when fn does not panic", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertPanics(c, func() {})
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), noPanic))
+		}).
+		Test("AssertNotPanics should pass when fn does not panic", func(t *testing.T) {
+			AssertNotPanics(t, func() {})
+		}).
+		Test("AssertNotPanics should report value and stack when fn panics", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertNotPanics(c, func() { panic("boom") })
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), "panic: boom"))
+			AssertTrue(t, strings.Contains(c.String(), "panic_test.go"))
+		}).
+		Test("AssertPanicsWithValue should pass when value is equal", func(t *testing.T) {
+			AssertPanicsWithValue(t, "boom", func() { panic("boom") })
+			AssertPanicsWithValue(t, []int{1}, func() { panic([]int{1}) })
+		}).
+		Test("AssertPanicsWithValue should fail when value is not equal", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertPanicsWithValue(c, "boom", func() { panic("bang") })
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), "panic: bang"))
+		}).
+		Test("AssertPanicsWithValue should fail when fn does not panic", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertPanicsWithValue(c, "boom", func() {})
+			})
+			AssertTrue(t, c.Failed())
+		}).
+		Test("AssertPanicsWithError should pass when error matches", func(t *testing.T) {
+			AssertPanicsWithError(t, errPanicTest, func() {
+				panic(fmt.Errorf("wrapped: %w", errPanicTest))
+			})
+		}).
+		Test("AssertPanicsWithError should fail when error does not match", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertPanicsWithError(c, errPanicTest, func() { panic(errors.New("other")) })
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), "panic: other"))
+		}).
+		Test("AssertPanicsWithError should fail when value is not an error", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertPanicsWithError(c, errPanicTest, func() { panic("panic test") })
+			})
+			AssertTrue(t, c.Failed())
+		}).
+		Run()
+	AssertNoError(t, err)
+}
@@ -0,0 +1,173 @@
+package odize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// lastFailedPath is the cache of failed tests, relative to the package directory go test runs in
+const lastFailedPath = ".odize/last-failed.json"
+
+// lastFailedMu guards the cache file against groups within a package running in parallel
+var lastFailedMu sync.Mutex
+
+// FailedTest - Test that failed in the previous run
+type FailedTest struct {
+	Package string `json:"package"`
+	Group   string `json:"group"`
+	Test    string `json:"test"`
+}
+
+// lastFailed - Contents of the failed test cache
+type lastFailed struct {
+	Failed []FailedTest `json:"failed"`
+}
+
+// filterRerunFailedTests skips entries that did not fail in the previous run, when ODIZE_RERUN_FAILED is set.
+// If no previous run has been recorded, all entries are run.
+func (tg *TestGroup) filterRerunFailedTests(entries []TestRegistryEntry) ([]TestRegistryEntry, error) {
+	tg.t.Helper()
+
+	if !tg.rerunFailed {
+		return entries, nil
+	}
+
+	lastFailedMu.Lock()
+	previous, found, err := readLastFailed(tg.lastFailedPath)
+	lastFailedMu.Unlock()
+
+	if err != nil {
+		return entries, err
+	}
+
+	if !found {
+		tg.t.Logf("no previous run recorded in %s, running all tests", tg.lastFailedPath)
+		return entries, nil
+	}
+
+	filtered := make([]TestRegistryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !previous.contains(tg.failedTest(entry.name)) {
+			filtered = append(filtered, skippedEntry(entry.name, fmt.Sprintf("did not fail in the previous run, %s is set", ODIZE_RERUN_FAILED)))
+			continue
+		}
+
+		filtered = append(filtered, entry)
+	}
+
+	return filtered, nil
+}
+
+// recordFailures replaces the cached result of each test that ran with the failures from this run.
+// Failures of tests that were skipped, e.g. by a filter or shard, are kept. The cache is only created once a test has failed.
+func (tg *TestGroup) recordFailures(ran []string, failed []string) {
+	tg.t.Helper()
+
+	if tg.lastFailedPath == "" {
+		return
+	}
+
+	lastFailedMu.Lock()
+	defer lastFailedMu.Unlock()
+
+	previous, found, err := readLastFailed(tg.lastFailedPath)
+	if err != nil {
+		tg.t.Logf("unable to read failed tests: %v", err)
+		return
+	}
+
+	if !found && len(failed) == 0 {
+		return
+	}
+
+	updated := lastFailed{Failed: []FailedTest{}}
+	for _, test := range previous.Failed {
+		if test.Package != tg.pkg || test.Group != tg.t.Name() || !slices.Contains(ran, test.Test) {
+			updated.Failed = append(updated.Failed, test)
+		}
+	}
+
+	for _, name := range failed {
+		updated.Failed = append(updated.Failed, tg.failedTest(name))
+	}
+
+	if err = writeLastFailed(tg.lastFailedPath, updated); err != nil {
+		tg.t.Logf("unable to record failed tests: %v", err)
+	}
+}
+
+func (tg *TestGroup) failedTest(name string) FailedTest {
+	return FailedTest{
+		Package: tg.pkg,
+		Group:   tg.t.Name(),
+		Test:    name,
+	}
+}
+
+func (lf lastFailed) contains(test FailedTest) bool {
+	return slices.Contains(lf.Failed, test)
+}
+
+// readLastFailed reads the cache, returning false if it does not exist
+func readLastFailed(path string) (lastFailed, bool, error) {
+	result := lastFailed{}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, false, nil
+	}
+
+	if err != nil {
+		return result, false, err
+	}
+
+	if err = json.Unmarshal(content, &result); err != nil {
+		return result, false, fmt.Errorf("invalid failed test cache %s: %w", path, err)
+	}
+
+	return result, true, nil
+}
+
+// writeLastFailed writes the cache, creating the directory if needed
+func writeLastFailed(path string, lf lastFailed) error {
+	content, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(content, '\n'), 0o600)
+}
+
+// callerPackage returns the import path of the package that called NewGroup
+func callerPackage() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return ""
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+
+	// e.g. github.com/code-gorilla-au/odize/examples.TestExample.func1
+	name := fn.Name()
+	lastSlash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[lastSlash+1:], "."); dot >= 0 {
+		return name[:lastSlash+1+dot]
+	}
+
+	return name
+}
@@ -0,0 +1,135 @@
+package odize
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRerunFailed(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	var path string
+
+	group.BeforeEach(func() {
+		path = filepath.Join(t.TempDir(), lastFailedPath)
+	})
+
+	err := group.
+		Test("should record failures for the group", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+			tg.lastFailedPath = path
+
+			tg.recordFailures([]string{"should fail"}, []string{"should fail"})
+
+			result, found, err := readLastFailed(path)
+			AssertNoError(t, err)
+			AssertTrue(t, found)
+			AssertEqual(t, []FailedTest{{Package: "github.com/code-gorilla-au/odize", Group: t.Name(), Test: "should fail"}}, result.Failed)
+		}).
+		Test("should replace previous failures of tests that ran", func(t *testing.T) {
+			other := FailedTest{Package: "other", Group: "TestOther", Test: "should fail"}
+			notRun := FailedTest{Package: "github.com/code-gorilla-au/odize", Group: t.Name(), Test: "not run"}
+			AssertNoError(t, writeLastFailed(path, lastFailed{Failed: []FailedTest{
+				other,
+				notRun,
+				{Package: "github.com/code-gorilla-au/odize", Group: t.Name(), Test: "now passing"},
+			}}))
+
+			tg := NewGroup(t, nil)
+			tg.lastFailedPath = path
+
+			tg.recordFailures([]string{"now passing"}, nil)
+
+			result, _, err := readLastFailed(path)
+			AssertNoError(t, err)
+			AssertEqual(t, []FailedTest{other, notRun}, result.Failed)
+		}).
+		Test("should not create cache when nothing failed", func(t *testing.T) {
+			tg := NewGroup(t, nil)
+			tg.lastFailedPath = path
+
+			tg.recordFailures([]string{"should pass"}, nil)
+
+			_, found, err := readLastFailed(path)
+			AssertNoError(t, err)
+			AssertFalse(t, found)
+		}).
+		Test("should only run previously failed tests", func(t *testing.T) {
+			AssertNoError(t, writeLastFailed(path, lastFailed{Failed: []FailedTest{
+				{Package: "github.com/code-gorilla-au/odize", Group: t.Name(), Test: "failed before"},
+			}}))
+			t.Setenv(ODIZE_RERUN_FAILED, "true")
+
+			var ran []string
+
+			tg := NewGroup(t, nil)
+			tg.lastFailedPath = path
+
+			err := tg.
+				Test("failed before", func(t *testing.T) {
+					ran = append(ran, "failed before")
+				}).
+				Test("passed before", func(t *testing.T) {
+					ran = append(ran, "passed before")
+				}).
+				Run()
+			AssertNoError(t, err)
+			AssertEqual(t, []string{"failed before"}, ran)
+		}).
+		Test("should run all tests when no previous run is recorded", func(t *testing.T) {
+			t.Setenv(ODIZE_RERUN_FAILED, "true")
+
+			ran := 0
+
+			tg := NewGroup(t, nil)
+			tg.lastFailedPath = path
+
+			err := tg.
+				Test("first", func(t *testing.T) {
+					ran++
+				}).
+				Test("second", func(t *testing.T) {
+					ran++
+				}).
+				Run()
+			AssertNoError(t, err)
+			AssertEqual(t, 2, ran)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestRecordFailuresOfParallelTests(t *testing.T) {
+	dir := t.TempDir()
+	_ = runTestProcessIn(t, dir, "TestProcessRerunParallel")
+
+	result, found, err := readLastFailed(filepath.Join(dir, lastFailedPath))
+	AssertNoError(t, err)
+	AssertTrue(t, found)
+	AssertElementsMatch(t, []FailedTest{
+		{Package: "github.com/code-gorilla-au/odize", Group: "TestProcessRerunParallel", Test: "parallel fails"},
+		{Package: "github.com/code-gorilla-au/odize", Group: "TestProcessRerunParallel", Test: "serial fails"},
+	}, result.Failed)
+}
+
+func TestProcessRerunParallel(t *testing.T) {
+	skipUnlessTestProcess(t)
+
+	_ = NewGroup(t, nil).
+		Test("parallel fails", func(t *testing.T) {
+			t.Parallel()
+			t.Error("parallel failure")
+		}).
+		Test("parallel passes", func(t *testing.T) {
+			t.Parallel()
+		}).
+		Test("serial fails", func(t *testing.T) {
+			t.Error("serial failure")
+		}).
+		Run()
+}
+
+func TestCallerPackage(t *testing.T) {
+	tg := NewGroup(t, nil)
+	AssertEqual(t, "github.com/code-gorilla-au/odize", tg.pkg)
+}
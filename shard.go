@@ -0,0 +1,118 @@
+package odize
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// shardCounts tracks the number of tests assigned to each shard across all groups in the package
+var shardCounts = struct {
+	sync.Mutex
+	total  int
+	counts map[int]int
+}{
+	counts: map[int]int{},
+}
+
+// shard - A subset of tests to run, index is 1 based
+type shard struct {
+	index int
+	total int
+}
+
+// ShardSummary - Summary of the number of tests assigned to each shard across all groups run so far in the package.
+// Returns an empty string if ODIZE_SHARD is not set. Useful in TestMain to check shards are balanced.
+func ShardSummary() string {
+	shardCounts.Lock()
+	defer shardCounts.Unlock()
+
+	if shardCounts.total == 0 {
+		return ""
+	}
+
+	return formatShardCounts(shardCounts.total, shardCounts.counts)
+}
+
+// filterShardTests skips entries that are not assigned to the shard set in ODIZE_SHARD.
+// Entries are assigned to a shard by a hash of the full test name, so every shard sees the same assignment.
+func (tg *TestGroup) filterShardTests(entries []TestRegistryEntry) ([]TestRegistryEntry, error) {
+	tg.t.Helper()
+
+	current, enabled, err := parseShard(tg.envShard)
+	if err != nil || !enabled {
+		return entries, err
+	}
+
+	counts := map[int]int{}
+	filtered := make([]TestRegistryEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		assigned := shardFor(tg.t.Name()+"/"+entry.name, current.total)
+		counts[assigned]++
+
+		if assigned != current.index {
+			filtered = append(filtered, skippedEntry(entry.name, fmt.Sprintf("not in shard %d/%d, assigned to shard %d", current.index, current.total, assigned)))
+			continue
+		}
+
+		filtered = append(filtered, entry)
+	}
+
+	recordShardCounts(current.total, counts)
+	tg.t.Logf("running shard %d/%d, %s", current.index, current.total, formatShardCounts(current.total, counts))
+
+	return filtered, nil
+}
+
+// parseShard parses a shard in the format "index/total"
+func parseShard(value string) (shard, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return shard{}, false, nil
+	}
+
+	rawIndex, rawTotal, found := strings.Cut(value, "/")
+	if !found {
+		return shard{}, false, fmt.Errorf("%w: %s=%s", ErrInvalidShard, ODIZE_SHARD, value)
+	}
+
+	index, indexErr := strconv.Atoi(strings.TrimSpace(rawIndex))
+	total, totalErr := strconv.Atoi(strings.TrimSpace(rawTotal))
+	if indexErr != nil || totalErr != nil || total < 1 || index < 1 || index > total {
+		return shard{}, false, fmt.Errorf("%w: %s=%s", ErrInvalidShard, ODIZE_SHARD, value)
+	}
+
+	return shard{index: index, total: total}, true, nil
+}
+
+// shardFor returns the 1 based shard a test is assigned to
+func shardFor(fullName string, total int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(fullName))
+
+	return int(hash.Sum32()%uint32(total)) + 1 //nolint:gosec // total is validated to be positive
+}
+
+// recordShardCounts adds a group's shard assignments to the package summary
+func recordShardCounts(total int, counts map[int]int) {
+	shardCounts.Lock()
+	defer shardCounts.Unlock()
+
+	shardCounts.total = total
+	for index, count := range counts {
+		shardCounts.counts[index] += count
+	}
+}
+
+// formatShardCounts formats the number of tests assigned to each shard
+func formatShardCounts(total int, counts map[int]int) string {
+	parts := make([]string, 0, total)
+	for index := 1; index <= total; index++ {
+		parts = append(parts, fmt.Sprintf("%d/%d: %d", index, total, counts[index]))
+	}
+
+	return "tests per shard [" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,86 @@
+package odize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseShard(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should be disabled when empty", func(t *testing.T) {
+			_, enabled, err := parseShard("")
+			AssertNoError(t, err)
+			AssertFalse(t, enabled)
+		}).
+		Test("should parse index and total", func(t *testing.T) {
+			result, enabled, err := parseShard("2/8")
+			AssertNoError(t, err)
+			AssertTrue(t, enabled)
+			AssertEqual(t, shard{index: 2, total: 8}, result)
+		}).
+		Test("should error without separator", func(t *testing.T) {
+			_, _, err := parseShard("2")
+			AssertTrue(t, errors.Is(err, ErrInvalidShard))
+		}).
+		Test("should error when index is out of range", func(t *testing.T) {
+			_, _, err := parseShard("5/4")
+			AssertTrue(t, errors.Is(err, ErrInvalidShard))
+		}).
+		Test("should error when index is zero", func(t *testing.T) {
+			_, _, err := parseShard("0/4")
+			AssertTrue(t, errors.Is(err, ErrInvalidShard))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestShardFor(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should be deterministic", func(t *testing.T) {
+			AssertEqual(t, shardFor("TestGroup/should pass", 8), shardFor("TestGroup/should pass", 8))
+		}).
+		Test("should assign within range", func(t *testing.T) {
+			for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+				assigned := shardFor(name, 3)
+				AssertTrue(t, assigned >= 1 && assigned <= 3)
+			}
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestShardShouldRunEachTestInExactlyOneShard(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	runs := map[string]int{}
+
+	for _, shardValue := range []string{"1/3", "2/3", "3/3"} {
+		t.Setenv(ODIZE_SHARD, shardValue)
+
+		tg := NewGroup(t, nil)
+		for _, name := range names {
+			tg.Test(name, func(t *testing.T) {
+				runs[name]++
+			})
+		}
+
+		AssertNoError(t, tg.Run())
+	}
+
+	for _, name := range names {
+		AssertEqual(t, 1, runs[name])
+	}
+
+	AssertTrue(t, strings.Contains(ShardSummary(), "tests per shard [1/3: "))
+}
+
+func TestFormatShardCounts(t *testing.T) {
+	result := formatShardCounts(3, map[int]int{1: 2, 3: 4})
+	AssertEqual(t, "tests per shard [1/3: 2, 2/3: 0, 3/3: 4]", result)
+}
@@ -0,0 +1,91 @@
+package odize
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	shuffleOn  = "on"
+	shuffleOff = "off"
+)
+
+var (
+	// generatedShuffleSeed is generated once per test binary, so one seed reproduces the order of every group
+	generatedShuffleSeed = sync.OnceValue(func() uint64 {
+		return uint64(time.Now().UnixNano())
+	})
+	shuffleSeedLogged sync.Once
+)
+
+// Shuffle - Run tests within the group in a random order, to surface tests that depend on each other.
+//
+// A single seed is used for every group in the test binary, and is logged once per run. Set the ODIZE_SHUFFLE environment variable to the seed to reproduce the order.
+// Shuffling can also be enabled for all groups with ODIZE_SHUFFLE=on.
+func (tg *TestGroup) Shuffle() *TestGroup {
+	tg.shuffle = true
+	return tg
+}
+
+// shuffleEntries shuffles the entries in place if shuffling is enabled, logging the seed once per test binary.
+// Each group's order is derived from the seed and the group's name, so groups are shuffled independently.
+func (tg *TestGroup) shuffleEntries(entries []TestRegistryEntry) error {
+	tg.t.Helper()
+
+	seed, enabled, err := resolveShuffleSeed(tg.shuffle, tg.envShuffle)
+	if err != nil || !enabled {
+		return err
+	}
+
+	shuffleSeedLogged.Do(func() {
+		_, _ = fmt.Fprintf(os.Stdout, "odize: shuffling tests with seed %d, reproduce with %s=%d\n", seed, ODIZE_SHUFFLE, seed)
+	})
+
+	//nolint:gosec // shuffling test order does not need a cryptographically secure source
+	random := rand.New(rand.NewPCG(seed, groupSeed(tg.t.Name())))
+	random.Shuffle(len(entries), func(i, j int) {
+		entries[i], entries[j] = entries[j], entries[i]
+	})
+
+	return nil
+}
+
+// resolveShuffleSeed determines if shuffling is enabled and the seed to use.
+// A seed provided via the environment takes priority, otherwise the seed generated for the test binary is used.
+func resolveShuffleSeed(groupShuffle bool, envShuffle string) (uint64, bool, error) {
+	value := strings.ToLower(strings.TrimSpace(envShuffle))
+
+	switch value {
+	case shuffleOff:
+		return 0, false, nil
+	case "":
+		if !groupShuffle {
+			return 0, false, nil
+		}
+
+		return generatedShuffleSeed(), true, nil
+	case shuffleOn:
+		return generatedShuffleSeed(), true, nil
+	}
+
+	seed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%w: %s=%s", ErrInvalidShuffle, ODIZE_SHUFFLE, envShuffle)
+	}
+
+	return seed, true, nil
+}
+
+// groupSeed hashes the group's name, giving each group its own order from the same seed
+func groupSeed(name string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+
+	return h.Sum64()
+}
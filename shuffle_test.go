@@ -0,0 +1,112 @@
+package odize
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveShuffleSeed(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should be disabled by default", func(t *testing.T) {
+			_, enabled, err := resolveShuffleSeed(false, "")
+			AssertNoError(t, err)
+			AssertFalse(t, enabled)
+		}).
+		Test("should enable when group opts in", func(t *testing.T) {
+			_, enabled, err := resolveShuffleSeed(true, "")
+			AssertNoError(t, err)
+			AssertTrue(t, enabled)
+		}).
+		Test("should enable when env is on", func(t *testing.T) {
+			_, enabled, err := resolveShuffleSeed(false, "ON")
+			AssertNoError(t, err)
+			AssertTrue(t, enabled)
+		}).
+		Test("should disable when env is off", func(t *testing.T) {
+			_, enabled, err := resolveShuffleSeed(true, "off")
+			AssertNoError(t, err)
+			AssertFalse(t, enabled)
+		}).
+		Test("should generate one seed per test binary", func(t *testing.T) {
+			first, _, err := resolveShuffleSeed(true, "")
+			AssertNoError(t, err)
+
+			second, _, err := resolveShuffleSeed(false, "on")
+			AssertNoError(t, err)
+
+			AssertEqual(t, first, second)
+		}).
+		Test("should use seed from env", func(t *testing.T) {
+			seed, enabled, err := resolveShuffleSeed(false, "42")
+			AssertNoError(t, err)
+			AssertTrue(t, enabled)
+			AssertEqual(t, uint64(42), seed)
+		}).
+		Test("should error on invalid env", func(t *testing.T) {
+			_, _, err := resolveShuffleSeed(false, "sometimes")
+			AssertTrue(t, errors.Is(err, ErrInvalidShuffle))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestShuffleShouldReproduceOrderWithSeed(t *testing.T) {
+	t.Setenv(ODIZE_SHUFFLE, "1234")
+
+	runOrder := func(t *testing.T) []string {
+		var order []string
+		tg := NewGroup(t, nil)
+		for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+			tg.Test(name, func(t *testing.T) {
+				order = append(order, name)
+			})
+		}
+
+		AssertNoError(t, tg.Run())
+		return order
+	}
+
+	first := runOrder(t)
+	second := runOrder(t)
+
+	AssertEqual(t, 8, len(first))
+	AssertEqual(t, first, second)
+	AssertFalse(t, isEqual([]string{"a", "b", "c", "d", "e", "f", "g", "h"}, first))
+}
+
+func TestShuffleShouldOrderGroupsIndependently(t *testing.T) {
+	t.Setenv(ODIZE_SHUFFLE, "1234")
+
+	orders := map[string][]string{}
+	for _, group := range []string{"users", "orders"} {
+		t.Run(group, func(t *testing.T) {
+			tg := NewGroup(t, nil)
+			for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+				tg.Test(name, func(t *testing.T) {
+					orders[group] = append(orders[group], name)
+				})
+			}
+
+			AssertNoError(t, tg.Run())
+		})
+	}
+
+	AssertEqual(t, 8, len(orders["users"]))
+	AssertFalse(t, isEqual(orders["users"], orders["orders"]))
+}
+
+func TestShuffleShouldFailGroupWithInvalidSeed(t *testing.T) {
+	t.Setenv(ODIZE_SHUFFLE, "sometimes")
+
+	tg := NewGroup(t, nil)
+	err := tg.
+		Test("should not run", func(t *testing.T) {
+			t.Error("should not run")
+		}).
+		Run()
+
+	AssertTrue(t, errors.Is(err, ErrInvalidShuffle))
+}
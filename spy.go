@@ -0,0 +1,421 @@
+package odize
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// SpyCall - Recorded call to a spy
+type SpyCall struct {
+	// Arguments the spy was called with
+	Args []any
+	// Values returned by the spy
+	Returns []any
+	// File and line of the code that called the spy
+	Caller string
+}
+
+// SpyRecorder - Records calls to a spy, used by the spy assertions
+type SpyRecorder interface {
+	// CallHistory - Calls made to the spy, in the order they were made
+	CallHistory() []SpyCall
+	// Reset - Clear the recorded calls
+	Reset()
+}
+
+// Spy - Spy for any function type F, recording each call.
+//
+// A spy returns zero values unless configured with Returns, ReturnsOnce or Calls.
+type Spy[F any] struct {
+	mu         sync.Mutex
+	fnType     reflect.Type
+	fn         F
+	impl       reflect.Value
+	returns    []reflect.Value
+	hasReturns bool
+	once       [][]reflect.Value
+	calls      []SpyCall
+}
+
+// Fn - Typed spy for a function that takes Args and returns Ret.
+// Use a struct for Args or Ret to spy on functions with multiple arguments or return values.
+type Fn[Args any, Ret any] struct {
+	mu    sync.Mutex
+	impl  func(Args) Ret
+	once  []Ret
+	calls []SpyCall
+}
+
+// NewSpy - Create a spy for the function type F. Panics if F is not a function type.
+//
+// Example:
+//
+//	fetch := odize.NewSpy[func(ctx context.Context, id string) (User, error)]().
+//		Returns(User{Name: "John"}, nil)
+//
+//	svc := NewService(fetch.Func())
+func NewSpy[F any]() *Spy[F] {
+	fnType := reflect.TypeFor[F]()
+	if fnType.Kind() != reflect.Func {
+		panic(fmt.Sprintf("odize: NewSpy requires a function type, got %s", fnType))
+	}
+
+	spy := &Spy[F]{
+		fnType: fnType,
+	}
+
+	fn, ok := reflect.MakeFunc(fnType, spy.call).Interface().(F)
+	if !ok {
+		panic(fmt.Sprintf("odize: unable to create spy for %s", fnType))
+	}
+
+	spy.fn = fn
+
+	return spy
+}
+
+// NewFn - Create a typed spy for a function that takes Args and returns Ret.
+//
+// Example:
+//
+//	validate := odize.NewFn[string, error]().Returns(nil)
+//
+//	svc := NewService(validate.Func())
+func NewFn[Args any, Ret any]() *Fn[Args, Ret] {
+	return &Fn[Args, Ret]{}
+}
+
+// Spies - Register spies on the group, resetting their call history after each test's AfterEach hook
+func (tg *TestGroup) Spies(spies ...SpyRecorder) *TestGroup {
+	tg.spies = append(tg.spies, spies...)
+
+	return tg
+}
+
+// Func - Function to inject into the code under test
+func (s *Spy[F]) Func() F {
+	return s.fn
+}
+
+// Returns - Return the values on every call. Replaces any implementation set by Calls.
+// Panics if the values do not match the function's return types.
+func (s *Spy[F]) Returns(values ...any) *Spy[F] {
+	results := s.resultValues(values)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.returns = results
+	s.hasReturns = true
+	s.impl = reflect.Value{}
+
+	return s
+}
+
+// ReturnsOnce - Return the values on the next call only, taking priority over Returns and Calls.
+// Multiple calls queue values in order. Panics if the values do not match the function's return types.
+func (s *Spy[F]) ReturnsOnce(values ...any) *Spy[F] {
+	results := s.resultValues(values)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.once = append(s.once, results)
+
+	return s
+}
+
+// Calls - Call impl on every call. Replaces any values set by Returns.
+func (s *Spy[F]) Calls(impl F) *Spy[F] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.impl = reflect.ValueOf(impl)
+	s.returns = nil
+	s.hasReturns = false
+
+	return s
+}
+
+// CallHistory - Calls made to the spy, in the order they were made
+func (s *Spy[F]) CallHistory() []SpyCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SpyCall{}, s.calls...)
+}
+
+// Reset - Clear the recorded calls. Configured return values and implementations are kept.
+func (s *Spy[F]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = nil
+}
+
+// call handles a call to the spied function
+func (s *Spy[F]) call(args []reflect.Value) []reflect.Value {
+	caller := spyCaller()
+
+	s.mu.Lock()
+	var results []reflect.Value
+	impl := s.impl
+
+	switch {
+	case len(s.once) > 0:
+		results = s.once[0]
+		s.once = s.once[1:]
+	case s.hasReturns:
+		results = s.returns
+	}
+	s.mu.Unlock()
+
+	if results == nil && impl.IsValid() {
+		if s.fnType.IsVariadic() {
+			results = impl.CallSlice(args)
+		} else {
+			results = impl.Call(args)
+		}
+	}
+
+	if results == nil {
+		results = make([]reflect.Value, s.fnType.NumOut())
+		for i := range results {
+			results[i] = reflect.Zero(s.fnType.Out(i))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, SpyCall{
+		Args:    interfaces(args),
+		Returns: interfaces(results),
+		Caller:  caller,
+	})
+
+	return results
+}
+
+// resultValues converts values to the function's return types, using the zero value for nil
+func (s *Spy[F]) resultValues(values []any) []reflect.Value {
+	if len(values) != s.fnType.NumOut() {
+		panic(fmt.Sprintf("odize: spy for %s returns %d values, got %d", s.fnType, s.fnType.NumOut(), len(values)))
+	}
+
+	results := make([]reflect.Value, len(values))
+	for i, value := range values {
+		out := s.fnType.Out(i)
+
+		if value == nil {
+			results[i] = reflect.Zero(out)
+			continue
+		}
+
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(out) {
+			panic(fmt.Sprintf("odize: spy for %s return value %d must be %s, got %s", s.fnType, i, out, v.Type()))
+		}
+
+		results[i] = reflect.New(out).Elem()
+		results[i].Set(v)
+	}
+
+	return results
+}
+
+// Func - Function to inject into the code under test
+func (f *Fn[Args, Ret]) Func() func(Args) Ret {
+	return func(args Args) Ret {
+		return f.Call(args)
+	}
+}
+
+// Call - Call the spy, recording the call
+func (f *Fn[Args, Ret]) Call(args Args) Ret {
+	caller := spyCaller()
+
+	f.mu.Lock()
+	var result Ret
+	impl := f.impl
+	once := len(f.once) > 0
+
+	if once {
+		result = f.once[0]
+		f.once = f.once[1:]
+	}
+	f.mu.Unlock()
+
+	if !once && impl != nil {
+		result = impl(args)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, SpyCall{
+		Args:    []any{args},
+		Returns: []any{result},
+		Caller:  caller,
+	})
+
+	return result
+}
+
+// Returns - Return ret on every call. Replaces any implementation set by Calls.
+func (f *Fn[Args, Ret]) Returns(ret Ret) *Fn[Args, Ret] {
+	return f.Calls(func(Args) Ret {
+		return ret
+	})
+}
+
+// ReturnsOnce - Return ret on the next call only, taking priority over Returns and Calls.
+// Multiple calls queue values in order.
+func (f *Fn[Args, Ret]) ReturnsOnce(ret Ret) *Fn[Args, Ret] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.once = append(f.once, ret)
+
+	return f
+}
+
+// Calls - Call impl on every call. Replaces any value set by Returns.
+func (f *Fn[Args, Ret]) Calls(impl func(Args) Ret) *Fn[Args, Ret] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.impl = impl
+
+	return f
+}
+
+// CallHistory - Calls made to the spy, in the order they were made
+func (f *Fn[Args, Ret]) CallHistory() []SpyCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]SpyCall{}, f.calls...)
+}
+
+// Reset - Clear the recorded calls. Configured return values and implementations are kept.
+func (f *Fn[Args, Ret]) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = nil
+}
+
+// AssertCalled checks the spy was called at least once
+//
+// Example:
+//
+//	AssertCalled(t, spy)
+func AssertCalled(t testing.TB, spy SpyRecorder) {
+	t.Helper()
+
+	if len(spy.CallHistory()) == 0 {
+		log(t, decorateDiff("at least 1 call", "0 calls"))
+	}
+}
+
+// AssertNotCalled checks the spy was never called
+//
+// Example:
+//
+//	AssertNotCalled(t, spy)
+func AssertNotCalled(t testing.TB, spy SpyRecorder) {
+	t.Helper()
+
+	calls := spy.CallHistory()
+	if len(calls) > 0 {
+		log(t, decorateDiff("0 calls", formatSpyCalls(calls)))
+	}
+}
+
+// AssertCalledTimes checks the spy was called exactly times
+//
+// Example:
+//
+//	AssertCalledTimes(t, spy, 2)
+func AssertCalledTimes(t testing.TB, spy SpyRecorder, times int) {
+	t.Helper()
+
+	calls := spy.CallHistory()
+	if len(calls) != times {
+		log(t, decorateDiff(fmt.Sprintf("%d calls", times), formatSpyCalls(calls)))
+	}
+}
+
+// AssertCalledWith checks at least one call to the spy was made with args. Arguments may be a Matcher e.g. Any().
+// Variadic arguments are recorded as a single slice. Typed Fn spies record their Args value as a single argument.
+//
+// Example:
+//
+//	AssertCalledWith(t, spy, ctx, "user-1")
+func AssertCalledWith(t testing.TB, spy SpyRecorder, args ...any) {
+	t.Helper()
+
+	calls := spy.CallHistory()
+	for _, call := range calls {
+		if matchArgs(args, call.Args) {
+			return
+		}
+	}
+
+	log(t, decorateDiff(fmt.Sprintf("call with %v", args), formatSpyCalls(calls)))
+}
+
+// formatSpyCalls lists each call with its arguments and caller
+func formatSpyCalls(calls []SpyCall) string {
+	if len(calls) == 0 {
+		return "0 calls"
+	}
+
+	lines := []string{fmt.Sprintf("%d calls", len(calls))}
+	for i, call := range calls {
+		lines = append(lines, fmt.Sprintf("%d: %v at %s", i+1, call.Args, call.Caller))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// resetSpies clears the call history of each spy registered on the group
+func (tg *TestGroup) resetSpies() {
+	for _, spy := range tg.spies {
+		spy.Reset()
+	}
+}
+
+// interfaces converts reflect values to their interface values
+func interfaces(values []reflect.Value) []any {
+	result := make([]any, len(values))
+	for i, value := range values {
+		result[i] = value.Interface()
+	}
+
+	return result
+}
+
+// spyCaller returns the file and line of the first caller outside the spy and reflect packages
+func spyCaller() string {
+	_, spyFile, _, _ := runtime.Caller(0)
+
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if frame.File != spyFile && !strings.HasPrefix(frame.Function, "reflect.") && !strings.HasPrefix(frame.Function, "runtime.") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package odize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSpy(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	var spy *Spy[func(id string, n int) (string, error)]
+
+	group.BeforeEach(func() {
+		spy = NewSpy[func(id string, n int) (string, error)]()
+	})
+
+	err := group.
+		Test("should return zero values by default", func(t *testing.T) {
+			result, err := spy.Func()("a", 1)
+			AssertEqual(t, "", result)
+			AssertNoError(t, err)
+		}).
+		Test("should record calls", func(t *testing.T) {
+			_, _ = spy.Func()("a", 1)
+			_, _ = spy.Func()("b", 2)
+
+			calls := spy.CallHistory()
+			AssertEqual(t, 2, len(calls))
+			AssertEqual(t, []any{"b", 2}, calls[1].Args)
+			AssertEqual(t, []any{"", nil}, calls[1].Returns)
+			AssertTrue(t, strings.Contains(calls[1].Caller, "spy_test.go:"))
+		}).
+		Test("should return values", func(t *testing.T) {
+			errExpected := errors.New("expected")
+			spy.Returns("value", errExpected)
+
+			result, err := spy.Func()("a", 1)
+			AssertEqual(t, "value", result)
+			AssertEqual(t, errExpected, err)
+		}).
+		Test("should return values once before default", func(t *testing.T) {
+			spy.Returns("default", nil).ReturnsOnce("first", nil).ReturnsOnce("second", nil)
+
+			var results []string
+			for range 3 {
+				result, _ := spy.Func()("a", 1)
+				results = append(results, result)
+			}
+
+			AssertEqual(t, []string{"first", "second", "default"}, results)
+		}).
+		Test("should call implementation", func(t *testing.T) {
+			spy.Calls(func(id string, n int) (string, error) {
+				return strings.Repeat(id, n), nil
+			})
+
+			result, _ := spy.Func()("a", 3)
+			AssertEqual(t, "aaa", result)
+			AssertEqual(t, []any{"aaa", nil}, spy.CallHistory()[0].Returns)
+		}).
+		Test("should panic when return values do not match", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				defer func() {
+					if recover() == nil {
+						c.Error("expected panic")
+					}
+				}()
+
+				spy.Returns(1, nil)
+			})
+			AssertFalse(t, c.Failed())
+		}).
+		Test("should reset calls", func(t *testing.T) {
+			_, _ = spy.Func()("a", 1)
+			spy.Reset()
+
+			AssertNotCalled(t, spy)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestSpyVariadic(t *testing.T) {
+	spy := NewSpy[func(format string, args ...any) string]().
+		Calls(func(format string, args ...any) string {
+			return format
+		})
+
+	result := spy.Func()("%s", "a", "b")
+
+	AssertEqual(t, "%s", result)
+	AssertCalledWith(t, spy, "%s", []any{"a", "b"})
+}
+
+func TestFnSpy(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	var fn *Fn[string, int]
+
+	group.BeforeEach(func() {
+		fn = NewFn[string, int]()
+	})
+
+	err := group.
+		Test("should return zero value by default", func(t *testing.T) {
+			AssertEqual(t, 0, fn.Func()("a"))
+		}).
+		Test("should return values once before default", func(t *testing.T) {
+			fn.Returns(1).ReturnsOnce(2)
+
+			AssertEqual(t, 2, fn.Func()("a"))
+			AssertEqual(t, 1, fn.Func()("a"))
+		}).
+		Test("should call implementation", func(t *testing.T) {
+			fn.Calls(func(s string) int {
+				return len(s)
+			})
+
+			AssertEqual(t, 3, fn.Func()("abc"))
+		}).
+		Test("should record calls", func(t *testing.T) {
+			fn.Returns(1)
+			fn.Func()("a")
+
+			calls := fn.CallHistory()
+			AssertEqual(t, []any{"a"}, calls[0].Args)
+			AssertEqual(t, []any{1}, calls[0].Returns)
+			AssertTrue(t, strings.Contains(calls[0].Caller, "spy_test.go:"))
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestSpiesResetAfterEach(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	fn := NewFn[string, int]()
+	afterEachCalls := 0
+
+	group.AfterEach(func() {
+		afterEachCalls = len(fn.CallHistory())
+	})
+
+	err := group.
+		Spies(fn).
+		Test("first", func(t *testing.T) {
+			fn.Func()("a")
+			AssertCalledTimes(t, fn, 1)
+		}).
+		Test("second", func(t *testing.T) {
+			AssertEqual(t, 1, afterEachCalls)
+			AssertNotCalled(t, fn)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestSpyAssertions(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	var fn *Fn[string, int]
+
+	group.BeforeEach(func() {
+		fn = NewFn[string, int]()
+	})
+
+	err := group.
+		Test("should pass when called", func(t *testing.T) {
+			fn.Func()("a")
+
+			AssertCalled(t, fn)
+			AssertCalledTimes(t, fn, 1)
+			AssertCalledWith(t, fn, "a")
+		}).
+		Test("should pass when not called", func(t *testing.T) {
+			AssertNotCalled(t, fn)
+			AssertCalledTimes(t, fn, 0)
+		}).
+		Test("should fail when not called", func(t *testing.T) {
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertCalled(c, fn)
+			})
+			AssertTrue(t, c.Failed())
+		}).
+		Test("should fail when called", func(t *testing.T) {
+			fn.Func()("a")
+
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertNotCalled(c, fn)
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), "1: [a] at "))
+		}).
+		Test("should fail when called a different number of times", func(t *testing.T) {
+			fn.Func()("a")
+
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertCalledTimes(c, fn, 2)
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), "2 calls"))
+		}).
+		Test("should fail when not called with args", func(t *testing.T) {
+			fn.Func()("a")
+
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertCalledWith(c, fn, "b")
+			})
+			AssertTrue(t, c.Failed())
+			AssertTrue(t, strings.Contains(c.String(), "call with [b]"))
+		}).
+		Run()
+	AssertNoError(t, err)
+}
@@ -0,0 +1,206 @@
+package odize
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/code-gorilla-au/env"
+)
+
+// suite tracks state across all groups within the package, reported by Main
+var suite = newSuiteState()
+
+// MainOpts - Options for Main
+type MainOpts = func(*mainConfig)
+
+type mainConfig struct {
+	beforeSuite func()
+	afterSuite  func()
+	tags        []string
+}
+
+// flakyTest - Test that only passed after a retry, with the output of each failed attempt
+type flakyTest struct {
+	name     string
+	failures []string
+}
+
+// suiteState - Package wide state shared by all groups
+type suiteState struct {
+	mu         sync.Mutex
+	tags       []string
+	onlyTests  []string
+	flakyTests []flakyTest
+	filter     string
+	filtered   int
+	matched    int
+	statuses   map[TestStatus][]string
+}
+
+// Main - Run the package tests with package wide setup, teardown and reporting. Call from TestMain.
+//
+// After all tests have run, Main reports flaky, todo and expected failure tests, shard balance,
+// and warns if ODIZE_FILTER did not match any tests.
+// In a CI environment, Main fails the package if any test used the 'Only' option, even within groups that were skipped.
+//
+// Example:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(odize.Main(m, odize.BeforeSuite(startDB), odize.AfterSuite(stopDB)))
+//	}
+func Main(m *testing.M, options ...MainOpts) int {
+	config := mainConfig{
+		beforeSuite: func() {},
+		afterSuite:  func() {},
+	}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	suite.setTags(config.tags)
+
+	config.beforeSuite()
+	code := m.Run()
+	config.afterSuite()
+
+	if err := suite.checkOnly(env.GetAsBool(ENV_CI)); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		code = 1
+	}
+
+	suite.report(os.Stdout)
+
+	return code
+}
+
+// BeforeSuite - Run once before any test in the package
+func BeforeSuite(fn func()) MainOpts {
+	return func(mc *mainConfig) {
+		mc.beforeSuite = fn
+	}
+}
+
+// AfterSuite - Run once after all tests in the package
+func AfterSuite(fn func()) MainOpts {
+	return func(mc *mainConfig) {
+		mc.afterSuite = fn
+	}
+}
+
+// WithTags - Add tags to every group within the package, in addition to the tags provided to NewGroup
+func WithTags(tags ...string) MainOpts {
+	return func(mc *mainConfig) {
+		mc.tags = append(mc.tags, tags...)
+	}
+}
+
+func newSuiteState() *suiteState {
+	return &suiteState{
+		statuses: map[TestStatus][]string{},
+	}
+}
+
+// setTags sets the tags added to every group
+func (s *suiteState) setTags(tags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tags = tags
+}
+
+// groupTags returns the group tags along with the package wide tags
+func (s *suiteState) groupTags(tags []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]string, 0, len(tags)+len(s.tags))
+	result = append(result, tags...)
+
+	return append(result, s.tags...)
+}
+
+// recordOnly records a test registered with the 'Only' option
+func (s *suiteState) recordOnly(fullName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onlyTests = append(s.onlyTests, fullName)
+}
+
+// recordFlaky records a test that only passed after a retry, along with the output of each failed attempt
+func (s *suiteState) recordFlaky(fullName string, failures []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flakyTests = append(s.flakyTests, flakyTest{name: fullName, failures: failures})
+}
+
+// recordStatus records a test that ran with a status other than a regular test
+func (s *suiteState) recordStatus(status TestStatus, fullName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statuses[status] = append(s.statuses[status], fullName)
+}
+
+// recordFilter records the number of tests checked and matched by ODIZE_FILTER within a group
+func (s *suiteState) recordFilter(filter string, checked int, matched int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.filter = filter
+	s.filtered += checked
+	s.matched += matched
+}
+
+// checkOnly returns an error if any test in the package used the 'Only' option within a CI environment
+func (s *suiteState) checkOnly(isCIEnv bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !isCIEnv || len(s.onlyTests) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrTestOptionNotAllowedInCI, strings.Join(s.onlyTests, ", "))
+}
+
+// report writes the package summary
+func (s *suiteState) report(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.flakyTests) > 0 {
+		_, _ = fmt.Fprintf(w, "odize: %d flaky tests passed after retry\n", len(s.flakyTests))
+		for _, flaky := range s.flakyTests {
+			_, _ = fmt.Fprintf(w, "\t%s\n", flaky.name)
+			for _, failure := range flaky.failures {
+				_, _ = fmt.Fprintf(w, "\t\t%s\n", strings.ReplaceAll(strings.TrimSpace(failure), "\n", "\n\t\t"))
+			}
+		}
+	}
+
+	for _, status := range []TestStatus{StatusTodo, StatusFails} {
+		names := s.statuses[status]
+		if len(names) == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "odize: %d %s tests\n", len(names), status)
+		for _, name := range names {
+			_, _ = fmt.Fprintf(w, "\t%s\n", name)
+		}
+	}
+
+	if s.filtered > 0 && s.matched == 0 {
+		_, _ = fmt.Fprintf(w, "odize: warning %s=%s did not match any of the %d tests in the package\n", ODIZE_FILTER, s.filter, s.filtered)
+	}
+
+	if summary := ShardSummary(); summary != "" {
+		_, _ = fmt.Fprintf(w, "odize: %s\n", summary)
+	}
+}
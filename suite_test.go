@@ -0,0 +1,78 @@
+package odize
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSuiteState(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should add package tags to group tags", func(t *testing.T) {
+			state := newSuiteState()
+			state.setTags([]string{"integration"})
+
+			AssertEqual(t, []string{"db", "integration"}, state.groupTags([]string{"db"}))
+		}).
+		Test("should allow only outside of CI", func(t *testing.T) {
+			state := newSuiteState()
+			state.recordOnly("TestGroup/debugging")
+
+			AssertNoError(t, state.checkOnly(false))
+		}).
+		Test("should fail only within CI", func(t *testing.T) {
+			state := newSuiteState()
+			state.recordOnly("TestGroup/debugging")
+
+			err := state.checkOnly(true)
+			AssertTrue(t, errors.Is(err, ErrTestOptionNotAllowedInCI))
+			AssertTrue(t, strings.Contains(err.Error(), "TestGroup/debugging"))
+		}).
+		Test("should report flaky tests", func(t *testing.T) {
+			state := newSuiteState()
+			state.recordFlaky("TestGroup/flaky", []string{"attempt 1 of 2 failed:\n    odize_test.go:10: connection refused"})
+
+			buf := new(bytes.Buffer)
+			state.report(buf)
+
+			AssertTrue(t, strings.Contains(buf.String(), "odize: 1 flaky tests passed after retry"))
+			AssertTrue(t, strings.Contains(buf.String(), "\tTestGroup/flaky\n"))
+			AssertTrue(t, strings.Contains(buf.String(), "\t\tattempt 1 of 2 failed:\n\t\t    odize_test.go:10: connection refused\n"))
+		}).
+		Run()
+
+	AssertNoError(t, err)
+}
+
+func TestMainOptions(t *testing.T) {
+	config := mainConfig{}
+
+	before := false
+	after := false
+
+	BeforeSuite(func() { before = true })(&config)
+	AfterSuite(func() { after = true })(&config)
+	WithTags("unit", "db")(&config)
+
+	config.beforeSuite()
+	config.afterSuite()
+
+	AssertTrue(t, before)
+	AssertTrue(t, after)
+	AssertEqual(t, []string{"unit", "db"}, config.tags)
+}
+
+func TestSuiteReportStatuses(t *testing.T) {
+	state := newSuiteState()
+	state.recordStatus(StatusTodo, "TestGroup/should support refunds")
+	state.recordStatus(StatusFails, "TestGroup/known bug")
+
+	buf := new(bytes.Buffer)
+	state.report(buf)
+
+	AssertTrue(t, strings.Contains(buf.String(), "odize: 1 todo tests\n\tTestGroup/should support refunds"))
+	AssertTrue(t, strings.Contains(buf.String(), "odize: 1 expected failure tests\n\tTestGroup/known bug"))
+}
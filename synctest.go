@@ -0,0 +1,38 @@
+package odize
+
+import (
+	"testing"
+	"testing/synctest"
+)
+
+// SyncTest - Add a test that runs inside a synctest bubble, equivalent to Test with the Synctest option.
+//
+// Within the bubble, time is fake and only advances once every goroutine in the bubble is blocked,
+// so tests using timers, sleeps and goroutines are fast and deterministic.
+//
+// BeforeAll, BeforeEach, AfterEach and AfterAll hooks run outside the bubble. Cleanup functions registered
+// with the test's t, and test scoped fixtures, run inside the bubble.
+//
+// Example:
+//
+//	group.SyncTest("should expire after a minute", func(t *testing.T) {
+//		cache := NewCache(time.Minute)
+//		cache.Set("key", "value")
+//
+//		time.Sleep(2 * time.Minute)
+//		synctest.Wait()
+//
+//		AssertFalse(t, cache.Has("key"))
+//	})
+func (tg *TestGroup) SyncTest(name string, testFn TestFn, options ...TestFuncOpts) *TestGroup {
+	return tg.Test(name, testFn, append(options, Synctest())...)
+}
+
+// synctestFn runs the test function inside a synctest bubble
+func synctestFn(testFn TestFn) TestFn {
+	return func(t *testing.T) {
+		t.Helper()
+
+		synctest.Test(t, testFn)
+	}
+}
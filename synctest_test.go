@@ -0,0 +1,60 @@
+package odize
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestSyncTest(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	// synctest bubbles start at midnight UTC 2000-01-01
+	bubbleStart := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var beforeEachNow time.Time
+
+	group.BeforeEach(func() {
+		beforeEachNow = time.Now()
+	})
+
+	err := group.
+		SyncTest("should run with fake time", func(t *testing.T) {
+			start := time.Now()
+			time.Sleep(time.Hour)
+
+			AssertEqual(t, time.Hour, time.Since(start))
+		}).
+		SyncTest("should wait for goroutines to block", func(t *testing.T) {
+			done := false
+			go func() {
+				time.Sleep(time.Minute)
+				done = true
+			}()
+
+			time.Sleep(time.Minute)
+			synctest.Wait()
+
+			AssertTrue(t, done)
+		}).
+		SyncTest("should run hooks outside the bubble", func(t *testing.T) {
+			AssertTrue(t, time.Now().Equal(bubbleStart))
+			AssertFalse(t, beforeEachNow.Equal(bubbleStart))
+		}).
+		SyncTest("should run cleanup inside the bubble", func(t *testing.T) {
+			t.Cleanup(func() {
+				AssertTrue(t, time.Now().Equal(bubbleStart.Add(time.Second)))
+			})
+
+			time.Sleep(time.Second)
+		}).
+		Test("should support the Synctest option", func(t *testing.T) {
+			AssertTrue(t, time.Now().Equal(bubbleStart))
+		}, Synctest()).
+		SyncTest("should support expected failures", func(t *testing.T) {
+			time.Sleep(time.Hour)
+			AssertTrue(t, false)
+		}, Fails()).
+		Run()
+	AssertNoError(t, err)
+}
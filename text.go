@@ -0,0 +1,259 @@
+package odize
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const (
+	// diffContext is the number of unchanged lines shown around each change by AssertLinesEqual
+	diffContext = 3
+	// maxDiffCells limits the size of the table used to diff the changed lines, about 32MB. Larger changes are reported without a line diff.
+	maxDiffCells = 1 << 22
+)
+
+// lineOp - Line of a line diff, prefixed by ' ' when unchanged, '+' when only expected, and '-' when only actual
+type lineOp struct {
+	kind     byte
+	line     string
+	expected int
+	actual   int
+}
+
+// AssertContainsString checks s contains substring
+//
+// Example:
+//
+//	AssertContainsString(t, err.Error(), "not found")
+func AssertContainsString(t testing.TB, s string, substring string) {
+	t.Helper()
+
+	if !strings.Contains(s, substring) {
+		log(t, fmt.Sprintf("%q does not contain %q", s, substring)+decorateDiff(fmt.Sprintf("contains %q", substring), s))
+	}
+}
+
+// AssertHasPrefix checks s starts with prefix
+//
+// Example:
+//
+//	AssertHasPrefix(t, id, "usr_")
+func AssertHasPrefix(t testing.TB, s string, prefix string) {
+	t.Helper()
+
+	if !strings.HasPrefix(s, prefix) {
+		log(t, fmt.Sprintf("%q does not have prefix %q", s, prefix)+decorateDiff(fmt.Sprintf("prefix %q", prefix), s))
+	}
+}
+
+// AssertHasSuffix checks s ends with suffix
+//
+// Example:
+//
+//	AssertHasSuffix(t, filename, ".json")
+func AssertHasSuffix(t testing.TB, s string, suffix string) {
+	t.Helper()
+
+	if !strings.HasSuffix(s, suffix) {
+		log(t, fmt.Sprintf("%q does not have suffix %q", s, suffix)+decorateDiff(fmt.Sprintf("suffix %q", suffix), s))
+	}
+}
+
+// AssertMatchesRegex checks s matches the regular expression pattern. Patterns are not anchored, use ^ and $ to match the whole string.
+//
+// Example:
+//
+//	AssertMatchesRegex(t, version, `^v\d+\.\d+\.\d+$`)
+func AssertMatchesRegex(t testing.TB, s string, pattern string) {
+	t.Helper()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		log(t, fmt.Sprintf("invalid pattern %q: %v", pattern, err))
+		return
+	}
+
+	if !re.MatchString(s) {
+		log(t, fmt.Sprintf("%q does not match %q", s, pattern)+decorateDiff(fmt.Sprintf("matches %s", pattern), s))
+	}
+}
+
+// AssertEqualFold checks expected and actual are equal, ignoring case
+//
+// Example:
+//
+//	AssertEqualFold(t, "content-type", header)
+func AssertEqualFold(t testing.TB, expected string, actual string) {
+	t.Helper()
+
+	if !strings.EqualFold(expected, actual) {
+		log(t, "strings are not equal ignoring case"+decorateDiff(expected, actual))
+	}
+}
+
+// AssertLinesEqual checks two multi-line strings are equal, reporting a line by line diff of the changed lines.
+// Changed lines show tabs as →, trailing spaces as ·, and carriage returns as ␍.
+//
+// Example:
+//
+//	AssertLinesEqual(t, expectedReport, report)
+func AssertLinesEqual(t testing.TB, expected string, actual string) {
+	t.Helper()
+
+	if expected == actual {
+		return
+	}
+
+	log(t, "lines are not equal\n"+decorateLineDiff(strings.Split(expected, "\n"), strings.Split(actual, "\n")))
+}
+
+// decorateLineDiff formats a unified diff of the lines, with the changed lines marked + when expected and - when actual
+func decorateLineDiff(expected []string, actual []string) string {
+	ops, ok := diffLines(expected, actual)
+	if !ok {
+		return "too many changed lines to diff" + decorateDiff(strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	buf := new(bytes.Buffer)
+
+	buf.WriteString("+++ Expected\n")
+	buf.WriteString("--- Got\n")
+
+	for _, hunk := range diffHunks(ops) {
+		first := ops[hunk[0]]
+		expectedLines, actualLines := 0, 0
+		for _, op := range ops[hunk[0]:hunk[1]] {
+			if op.kind != '-' {
+				expectedLines++
+			}
+
+			if op.kind != '+' {
+				actualLines++
+			}
+		}
+
+		_, _ = fmt.Fprintf(buf, "@@ +%s -%s @@\n", hunkRange(first.expected, expectedLines), hunkRange(first.actual, actualLines))
+
+		for _, op := range ops[hunk[0]:hunk[1]] {
+			line := op.line
+			if op.kind != ' ' {
+				line = visualiseWhitespace(line)
+			}
+
+			_, _ = fmt.Fprintf(buf, "%c%s\n", op.kind, line)
+		}
+	}
+
+	return buf.String()
+}
+
+// diffLines returns the operations turning actual into expected, using the longest common subsequence of the lines between
+// the common prefix and suffix. Returns false if the changed lines need a table larger than maxDiffCells.
+func diffLines(expected []string, actual []string) ([]lineOp, bool) {
+	prefix := 0
+	for prefix < len(expected) && prefix < len(actual) && expected[prefix] == actual[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(expected)-prefix && suffix < len(actual)-prefix && expected[len(expected)-1-suffix] == actual[len(actual)-1-suffix] {
+		suffix++
+	}
+
+	changedExpected := expected[prefix : len(expected)-suffix]
+	changedActual := actual[prefix : len(actual)-suffix]
+
+	rows, columns := len(changedExpected)+1, len(changedActual)+1
+	if rows*columns > maxDiffCells {
+		return nil, false
+	}
+
+	// common[i*columns+j] is the length of the longest common subsequence of changedExpected[i:] and changedActual[j:]
+	common := make([]int, rows*columns)
+	for i := len(changedExpected) - 1; i >= 0; i-- {
+		for j := len(changedActual) - 1; j >= 0; j-- {
+			if changedExpected[i] == changedActual[j] {
+				common[i*columns+j] = common[(i+1)*columns+j+1] + 1
+			} else {
+				common[i*columns+j] = max(common[(i+1)*columns+j], common[i*columns+j+1])
+			}
+		}
+	}
+
+	ops := make([]lineOp, 0, len(expected)+len(actual))
+	for i := range prefix {
+		ops = append(ops, lineOp{kind: ' ', line: expected[i], expected: i, actual: i})
+	}
+
+	i, j := 0, 0
+	for i < len(changedExpected) || j < len(changedActual) {
+		switch {
+		case i < len(changedExpected) && j < len(changedActual) && changedExpected[i] == changedActual[j]:
+			ops = append(ops, lineOp{kind: ' ', line: changedExpected[i], expected: prefix + i, actual: prefix + j})
+			i++
+			j++
+		case j < len(changedActual) && (i == len(changedExpected) || common[i*columns+j+1] >= common[(i+1)*columns+j]):
+			ops = append(ops, lineOp{kind: '-', line: changedActual[j], expected: prefix + i, actual: prefix + j})
+			j++
+		default:
+			ops = append(ops, lineOp{kind: '+', line: changedExpected[i], expected: prefix + i, actual: prefix + j})
+			i++
+		}
+	}
+
+	for k := range suffix {
+		ops = append(ops, lineOp{kind: ' ', line: expected[len(expected)-suffix+k], expected: len(expected) - suffix + k, actual: len(actual) - suffix + k})
+	}
+
+	return ops, true
+}
+
+// diffHunks returns the [start, end) ranges of ops to show, each change with up to diffContext unchanged lines either side.
+// Hunks closer than twice the context are merged.
+func diffHunks(ops []lineOp) [][2]int {
+	var hunks [][2]int
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+
+		start, end := max(i-diffContext, 0), min(i+diffContext+1, len(ops))
+		if last := len(hunks) - 1; last >= 0 && start <= hunks[last][1] {
+			hunks[last][1] = end
+			continue
+		}
+
+		hunks = append(hunks, [2]int{start, end})
+	}
+
+	return hunks
+}
+
+// hunkRange formats the 1 based start line and number of lines of a hunk, e.g. 4,3
+func hunkRange(start int, lines int) string {
+	if lines == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, lines)
+}
+
+// visualiseWhitespace shows tabs as →, trailing spaces as ·, and carriage returns as ␍
+func visualiseWhitespace(line string) string {
+	line, crlf := strings.CutSuffix(line, "\r")
+
+	line = strings.ReplaceAll(line, "\r", "␍")
+	line = strings.ReplaceAll(line, "\t", "→")
+
+	trimmed := strings.TrimRight(line, " ")
+	line = trimmed + strings.Repeat("·", len(line)-len(trimmed))
+
+	if crlf {
+		line += "␍"
+	}
+
+	return line
+}
@@ -0,0 +1,177 @@
+package odize
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAssertStrings(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when string contains substring", func(t *testing.T) {
+			AssertContainsString(t, "user not found", "not found")
+		}).
+		Test("should fail when string does not contain substring", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertContainsString(c, "user not found", "forbidden")
+			}, `"user not found" does not contain "forbidden"`)
+		}).
+		Test("should check prefix and suffix", func(t *testing.T) {
+			AssertHasPrefix(t, "usr_123", "usr_")
+			AssertHasSuffix(t, "report.json", ".json")
+		}).
+		Test("should fail without prefix or suffix", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertHasPrefix(c, "org_123", "usr_")
+			}, `"org_123" does not have prefix "usr_"`)
+			assertFails(t, func(c *Collect) {
+				AssertHasSuffix(c, "report.yaml", ".json")
+			}, `"report.yaml" does not have suffix ".json"`)
+		}).
+		Test("should match regex", func(t *testing.T) {
+			AssertMatchesRegex(t, "v1.2.3", `^v\d+\.\d+\.\d+$`)
+		}).
+		Test("should fail when regex does not match", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertMatchesRegex(c, "1.2", `^v\d+`)
+			}, `"1.2" does not match "^v\\d+"`)
+		}).
+		Test("should fail with an invalid pattern", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertMatchesRegex(c, "a", `(`)
+			}, `invalid pattern "("`)
+		}).
+		Test("should compare ignoring case", func(t *testing.T) {
+			AssertEqualFold(t, "Content-Type", "content-type")
+		}).
+		Test("should fail when different ignoring case", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertEqualFold(c, "Content-Type", "content-length")
+			}, "strings are not equal ignoring case")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestAssertLinesEqual(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should pass when equal", func(t *testing.T) {
+			AssertLinesEqual(t, "a\nb\n", "a\nb\n")
+		}).
+		Test("should show only changed lines with context", func(t *testing.T) {
+			expected := "1\n2\n3\n4\n5\n6\n7\n8\n9"
+			actual := "1\n2\n3\n4\nfive\n6\n7\n8\n9"
+
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertLinesEqual(c, expected, actual)
+			})
+			AssertTrue(t, c.Failed())
+
+			AssertContainsString(t, c.String(), strings.Join([]string{
+				"+++ Expected",
+				"--- Got",
+				"@@ +2,7 -2,7 @@",
+				" 2",
+				" 3",
+				" 4",
+				"-five",
+				"+5",
+				" 6",
+				" 7",
+				" 8",
+			}, "\n"))
+			AssertNotContains(t, c.String(), " 1\n")
+		}).
+		Test("should visualise whitespace in changed lines", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertLinesEqual(c, "\tname: a\nvalue", "  name: a \r\nvalue")
+			}, "+→name: a\n", "-  name: a·␍\n", " value\n")
+		}).
+		Test("should show added and removed lines", func(t *testing.T) {
+			assertFails(t, func(c *Collect) {
+				AssertLinesEqual(c, "a\nb", "a\nb\nc")
+			}, "@@ +1,2 -1,3 @@", "-c\n")
+		}).
+		Test("should diff a change within large inputs", func(t *testing.T) {
+			expected := numberedLines(10_000, "line")
+			actual := slices.Clone(expected)
+			actual[5_000] = "changed"
+
+			assertFails(t, func(c *Collect) {
+				AssertLinesEqual(c, strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+			}, "@@ +4998,7 -4998,7 @@", "-changed\n+line 5001\n")
+		}).
+		Test("should report large inputs without a line diff when every line changed", func(t *testing.T) {
+			expected := strings.Join(numberedLines(10_000, "expected"), "\n")
+			actual := strings.Join(numberedLines(10_000, "actual"), "\n")
+
+			c := runCollectAttempt(t, func(c *Collect) {
+				AssertLinesEqual(c, expected, actual)
+			})
+			AssertTrue(t, c.Failed())
+			AssertContainsString(t, c.String(), "too many changed lines to diff")
+			AssertNotContains(t, c.String(), "@@")
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestDiffLines(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should keep line numbers of the common prefix and suffix", func(t *testing.T) {
+			ops, ok := diffLines([]string{"a", "b", "c"}, []string{"a", "B", "c"})
+			AssertTrue(t, ok)
+			AssertEqual(t, []lineOp{
+				{kind: ' ', line: "a", expected: 0, actual: 0},
+				{kind: '-', line: "B", expected: 1, actual: 1},
+				{kind: '+', line: "b", expected: 1, actual: 2},
+				{kind: ' ', line: "c", expected: 2, actual: 2},
+			}, ops)
+		}).
+		Test("should not diff when the changed lines exceed the table limit", func(t *testing.T) {
+			_, ok := diffLines(numberedLines(3_000, "expected"), numberedLines(3_000, "actual"))
+			AssertFalse(t, ok)
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func TestDiffHunks(t *testing.T) {
+	group := NewGroup(t, nil)
+
+	err := group.
+		Test("should split distant changes into hunks", func(t *testing.T) {
+			expected := strings.Split("a\n1\n2\n3\n4\n5\n6\n7\n8\nb", "\n")
+			actual := strings.Split("A\n1\n2\n3\n4\n5\n6\n7\n8\nB", "\n")
+
+			ops, ok := diffLines(expected, actual)
+			AssertTrue(t, ok)
+			AssertEqual(t, [][2]int{{0, 5}, {7, 12}}, diffHunks(ops))
+		}).
+		Test("should merge nearby changes", func(t *testing.T) {
+			expected := strings.Split("a\n1\n2\nb", "\n")
+			actual := strings.Split("A\n1\n2\nB", "\n")
+
+			ops, ok := diffLines(expected, actual)
+			AssertTrue(t, ok)
+			AssertEqual(t, [][2]int{{0, 6}}, diffHunks(ops))
+		}).
+		Run()
+	AssertNoError(t, err)
+}
+
+func numberedLines(count int, prefix string) []string {
+	lines := make([]string, count)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%s %d", prefix, i+1)
+	}
+
+	return lines
+}
@@ -1,7 +1,9 @@
 package odize
 
 import (
+	"sync"
 	"testing"
+	"time"
 )
 
 // TestGroup - Group tests together, contains lifecycle context.
@@ -14,11 +16,32 @@ type TestGroup struct {
 	groupTags  []string
 	envTags    []string
 	skipped    bool
-	complete   bool
+	started    bool
 	registry   []TestRegistryEntry
 	cache      map[string]struct{}
 	errors     ListError
 	isCIEnv    bool
+	timeout    time.Duration
+	retry      int
+	shuffle    bool
+	envShuffle string
+	envShard   string
+	envFilter  string
+	// package of the test function that created the group
+	pkg            string
+	rerunFailed    bool
+	lastFailedPath string
+	spies          []SpyRecorder
+	fakeClocks     []fakeClock
+	detectLeaks    bool
+	leakIgnore     []string
+	// tests that ran, tests that failed, and flaky tests, recorded as each test completes
+	resultsMu sync.Mutex
+	ran       []string
+	failed    []string
+	flaky     []error
+	// number of flaky tests returned by Run, parallel tests complete after Run returns
+	flakyReturned int
 }
 
 // TestFn - Test function
@@ -31,17 +54,49 @@ type TestRegistryEntry struct {
 	// Test function to execute with context
 	fn      TestFn
 	options TestOpts
+	status  TestStatus
+	// Test was filtered out and will only report the skip reason
+	skipped bool
 }
 
+// TestStatus - Kind of test within the registry
+type TestStatus int
+
+const (
+	// StatusTest - Regular test
+	StatusTest TestStatus = iota
+	// StatusTodo - Placeholder for a test that has not been written yet
+	StatusTodo
+	// StatusFails - Test for a known bug, expected to fail
+	StatusFails
+)
+
 type TestFuncOpts = func(*TestOpts)
 
 // TestOpts - Test options for granular control over each test
 type TestOpts struct {
-	Only bool
-	Skip bool
+	Only           bool
+	Skip           bool
+	SkipInCI       bool
+	SkipConditions []SkipCondition
+	Timeout        time.Duration
+	Retry          int
+	Fails          bool
+	Synctest       bool
 }
 
+// SkipCondition - Evaluated before a test runs, returns true and the reason to skip the test
+type SkipCondition = func() (bool, string)
+
 // ListError - keep track of a number of errors
 type ListError struct {
 	errors []error
 }
+
+// Collect - Collects assertion failures for a single attempt within AssertEventuallyCollect or AssertConsistentlyCollect.
+// Collect can be passed to any odize assertion in place of *testing.T.
+type Collect struct {
+	testing.TB
+	messages []string
+	failed   bool
+}